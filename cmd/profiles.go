@@ -15,7 +15,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/elastic/elastic-package/internal/cobraext"
-	"github.com/elastic/elastic-package/internal/configuration/locations"
 	"github.com/elastic/elastic-package/internal/profile"
 )
 
@@ -44,11 +43,8 @@ User profiles are not overwritten on upgrade of elastic-stack, and can be freely
 	profileNewCommand := &cobra.Command{
 		Use:   "create",
 		Short: "Create a new profile",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-
-			if len(args) == 0 {
-				return errors.New("create requires an argument")
-			}
 			newProfileName := args[0]
 
 			fromName, err := cmd.Flags().GetString(cobraext.ProfileFromFlagName)
@@ -74,10 +70,8 @@ User profiles are not overwritten on upgrade of elastic-stack, and can be freely
 	profileDeleteCommand := &cobra.Command{
 		Use:   "delete",
 		Short: "Delete a profile",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) == 0 {
-				return errors.New("delete requires an argument")
-			}
 			profileName := args[0]
 
 			err := profile.DeleteProfile(profileName)
@@ -91,24 +85,44 @@ User profiles are not overwritten on upgrade of elastic-stack, and can be freely
 		},
 	}
 
-	profileListCommand := &cobra.Command{
-		Use:   "list",
-		Short: "List available profiles",
+	profileCopyCommand := &cobra.Command{
+		Use:   "copy",
+		Short: "Copy an existing profile to a new profile",
+		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			loc, err := locations.NewLocationManager()
+			srcName, dstName := args[0], args[1]
+
+			overwrite, err := cmd.Flags().GetBool(cobraext.ProfileOverwriteFlagName)
 			if err != nil {
-				return errors.Wrap(err, "error fetching profile")
+				return cobraext.FlagParsingError(err, cobraext.ProfileOverwriteFlagName)
 			}
-			profileList, err := profile.FetchAllProfiles(loc.ProfileDir())
+
+			err = profile.CopyProfile(srcName, dstName, overwrite)
 			if err != nil {
-				return errors.Wrap(err, "error listing all profiles")
+				return errors.Wrapf(err, "error copying profile %s to %s", srcName, dstName)
 			}
 
+			fmt.Printf("Copied profile %s to %s.\n", srcName, dstName)
+
+			return nil
+		},
+	}
+	profileCopyCommand.Flags().Bool(cobraext.ProfileOverwriteFlagName, false, cobraext.ProfileOverwriteFlagDescription)
+
+	profileListCommand := &cobra.Command{
+		Use:   "list",
+		Short: "List available profiles",
+		RunE: func(cmd *cobra.Command, args []string) error {
 			format, err := cmd.Flags().GetString(cobraext.ProfileFormatFlagName)
 			if err != nil {
 				return cobraext.FlagParsingError(err, cobraext.ProfileFromFlagName)
 			}
 
+			profileList, err := profile.ListProfiles()
+			if err != nil {
+				return errors.Wrap(err, "error listing all profiles")
+			}
+
 			switch format {
 			case tableFormat:
 				return formatTable(profileList)
@@ -121,12 +135,42 @@ User profiles are not overwritten on upgrade of elastic-stack, and can be freely
 	}
 	profileListCommand.Flags().String(cobraext.ProfileFormatFlagName, tableFormat, cobraext.ProfileFormatFlagDescription)
 
-	profileCommand.AddCommand(profileNewCommand, profileDeleteCommand, profileListCommand)
+	profileDiffCommand := &cobra.Command{
+		Use:   "diff",
+		Short: "Show differences between two profiles",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nameA, nameB := args[0], args[1]
+
+			diffs, err := profile.DiffProfiles(nameA, nameB)
+			if err != nil {
+				return errors.Wrap(err, "error comparing profiles")
+			}
+
+			if len(diffs) == 0 {
+				fmt.Printf("Profiles %s and %s are identical.\n", nameA, nameB)
+				return nil
+			}
+			for _, d := range diffs {
+				switch {
+				case d.OnlyInA:
+					fmt.Printf("Only in %s: %s\n", nameA, d.File)
+				case d.OnlyInB:
+					fmt.Printf("Only in %s: %s\n", nameB, d.File)
+				case d.Changed:
+					fmt.Printf("--- %s/%s\n+++ %s/%s\n%s\n", nameA, d.File, nameB, d.File, d.Diff)
+				}
+			}
+			return nil
+		},
+	}
+
+	profileCommand.AddCommand(profileNewCommand, profileCopyCommand, profileDeleteCommand, profileListCommand, profileDiffCommand)
 
 	return cobraext.NewCommand(profileCommand, cobraext.ContextGlobal)
 }
 
-func formatJSON(profileList []profile.Metadata) error {
+func formatJSON(profileList []profile.ProfileInfo) error {
 	data, err := json.Marshal(profileList)
 	if err != nil {
 		return errors.Wrap(err, "error listing all profiles in JSON format")
@@ -137,17 +181,18 @@ func formatJSON(profileList []profile.Metadata) error {
 	return nil
 }
 
-func formatTable(profileList []profile.Metadata) error {
+func formatTable(profileList []profile.ProfileInfo) error {
 	table := tablewriter.NewWriter(os.Stdout)
 	var profilesTable = profileToList(profileList)
 
-	table.SetHeader([]string{"Name", "Date Created", "User", "Version", "Path"})
+	table.SetHeader([]string{"Name", "Default", "Date Created", "User", "Version", "Path"})
 	table.SetHeaderColor(
 		twColor(tablewriter.Colors{tablewriter.Bold}),
 		twColor(tablewriter.Colors{tablewriter.Bold}),
 		twColor(tablewriter.Colors{tablewriter.Bold}),
 		twColor(tablewriter.Colors{tablewriter.Bold}),
 		twColor(tablewriter.Colors{tablewriter.Bold}),
+		twColor(tablewriter.Colors{tablewriter.Bold}),
 	)
 	table.SetColumnColor(
 		twColor(tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor}),
@@ -155,6 +200,7 @@ func formatTable(profileList []profile.Metadata) error {
 		tablewriter.Colors{},
 		tablewriter.Colors{},
 		tablewriter.Colors{},
+		tablewriter.Colors{},
 	)
 
 	table.SetAutoMergeCells(false)
@@ -165,10 +211,14 @@ func formatTable(profileList []profile.Metadata) error {
 	return nil
 }
 
-func profileToList(profiles []profile.Metadata) [][]string {
+func profileToList(profiles []profile.ProfileInfo) [][]string {
 	var profileList [][]string
 	for _, profile := range profiles {
-		profileList = append(profileList, []string{profile.Name, profile.DateCreated.Format(time.RFC3339), profile.User, profile.Version, profile.Path})
+		var isDefault string
+		if profile.IsDefault {
+			isDefault = "*"
+		}
+		profileList = append(profileList, []string{profile.Name, isDefault, profile.CreatedAt.Format(time.RFC3339), profile.User, profile.Version, profile.Path})
 	}
 
 	return profileList
@@ -184,15 +234,10 @@ func lookupEnv() string {
 }
 
 func availableProfilesAsAList() ([]string, error) {
-	loc, err := locations.NewLocationManager()
-	if err != nil {
-		return []string{}, errors.Wrap(err, "error fetching profile path")
-	}
-
 	profileNames := []string{}
-	profileList, err := profile.FetchAllProfiles(loc.ProfileDir())
+	profileList, err := profile.ListProfiles()
 	if err != nil {
-		return profileNames, errors.Wrap(err, "error fetching all profiles")
+		return profileNames, errors.Wrap(err, "error listing profiles")
 	}
 	for _, prof := range profileList {
 		profileNames = append(profileNames, prof.Name)
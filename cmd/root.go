@@ -5,15 +5,21 @@
 package cmd
 
 import (
+	"os"
 	"sort"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/elastic/elastic-package/internal/cobraext"
 	"github.com/elastic/elastic-package/internal/logger"
+	"github.com/elastic/elastic-package/internal/profile"
 	"github.com/elastic/elastic-package/internal/version"
 )
 
+// logLevelEnvVar is the environment variable used to select the log level.
+const logLevelEnvVar = "ELASTIC_PACKAGE_LOG_LEVEL"
+
 var commands = []*cobraext.Command{
 	setupBuildCommand(),
 	setupCheckCommand(),
@@ -44,11 +50,14 @@ func RootCmd() *cobra.Command {
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			return cobraext.ComposeCommandActions(cmd, args,
 				processPersistentFlags,
+				processProfileFromEnvFlag,
 				checkVersionUpdate,
 			)
 		},
 	}
 	rootCmd.PersistentFlags().BoolP(cobraext.VerboseFlagName, "v", false, cobraext.VerboseFlagDescription)
+	rootCmd.PersistentFlags().String(cobraext.LogFormatFlagName, logger.TextFormat, cobraext.LogFormatFlagDescription)
+	rootCmd.PersistentFlags().String(cobraext.ProfileFromEnvFlagName, "", cobraext.ProfileFromEnvFlagDescription)
 
 	for _, cmd := range commands {
 		rootCmd.AddCommand(cmd.Command)
@@ -71,12 +80,58 @@ func processPersistentFlags(cmd *cobra.Command, args []string) error {
 		return cobraext.FlagParsingError(err, cobraext.VerboseFlagName)
 	}
 
+	logFormat, err := cmd.Flags().GetString(cobraext.LogFormatFlagName)
+	if err != nil {
+		return cobraext.FlagParsingError(err, cobraext.LogFormatFlagName)
+	}
+
+	err = logger.SetFormat(logFormat)
+	if err != nil {
+		return errors.Wrapf(err, "invalid --%s flag", cobraext.LogFormatFlagName)
+	}
+
+	if logLevel := os.Getenv(logLevelEnvVar); logLevel != "" {
+		err = logger.SetLevel(logLevel)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %s environment variable", logLevelEnvVar)
+		}
+	}
+
 	if verbose {
 		logger.EnableDebugMode()
 	}
 	return nil
 }
 
+// processProfileFromEnvFlag sets the profile of commands supporting profiles to the value of the environment
+// variable selected with --profile-from-env. The profile explicitly set with --profile takes precedence.
+func processProfileFromEnvFlag(cmd *cobra.Command, args []string) error {
+	envName, err := cmd.Flags().GetString(cobraext.ProfileFromEnvFlagName)
+	if err != nil {
+		return cobraext.FlagParsingError(err, cobraext.ProfileFromEnvFlagName)
+	}
+	if envName == "" {
+		return nil
+	}
+
+	profileFlag := cmd.Flags().Lookup(cobraext.ProfileFlagName)
+	if profileFlag == nil || profileFlag.Changed {
+		return nil
+	}
+
+	profileName := os.Getenv(envName)
+	if profileName == "" {
+		logger.Debugf("Environment variable %s is not set, using the default profile", envName)
+		profileName = profile.DefaultProfile
+	}
+
+	err = cmd.Flags().Set(cobraext.ProfileFlagName, profileName)
+	if err != nil {
+		return cobraext.FlagParsingError(err, cobraext.ProfileFlagName)
+	}
+	return nil
+}
+
 func checkVersionUpdate(cmd *cobra.Command, args []string) error {
 	version.CheckUpdate()
 	return nil
@@ -5,14 +5,20 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/olekukonko/tablewriter"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/elastic/elastic-package/internal/cobraext"
 	"github.com/elastic/elastic-package/internal/common"
+	"github.com/elastic/elastic-package/internal/docker"
 	"github.com/elastic/elastic-package/internal/install"
 	"github.com/elastic/elastic-package/internal/profile"
 	"github.com/elastic/elastic-package/internal/stack"
@@ -72,6 +78,44 @@ func setupStackCommand() *cobraext.Command {
 				return cobraext.FlagParsingError(err, cobraext.StackVersionFlagName)
 			}
 
+			overrideFiles, err := cmd.Flags().GetStringSlice(cobraext.StackOverrideFileFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackOverrideFileFlagName)
+			}
+
+			setEnvs, err := cmd.Flags().GetStringArray(cobraext.StackSetEnvFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackSetEnvFlagName)
+			}
+
+			extraEnv, err := parseSetEnvFlag(setEnvs)
+			if err != nil {
+				return errors.Wrapf(err, "invalid --%s flag", cobraext.StackSetEnvFlagName)
+			}
+
+			wait, err := cmd.Flags().GetBool(cobraext.StackWaitFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackWaitFlagName)
+			}
+			if wait && !daemonMode {
+				return fmt.Errorf("--%s requires --%s", cobraext.StackWaitFlagName, cobraext.DaemonModeFlagName)
+			}
+
+			waitTimeout, err := cmd.Flags().GetDuration(cobraext.StackWaitTimeoutFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackWaitTimeoutFlagName)
+			}
+
+			dockerHost, err := dockerHostFlag(cmd)
+			if err != nil {
+				return err
+			}
+
+			noPull, err := cmd.Flags().GetBool(cobraext.StackNoPullFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackNoPullFlagName)
+			}
+
 			profileName, err := cmd.Flags().GetString(cobraext.ProfileFlagName)
 			if err != nil {
 				return cobraext.FlagParsingError(err, cobraext.ProfileFlagName)
@@ -88,14 +132,25 @@ func setupStackCommand() *cobraext.Command {
 			if err != nil {
 				return errors.Wrap(err, "error loading profile")
 			}
+
+			err = profile.ValidateProfile(profileName)
+			if err != nil {
+				return errors.Wrap(err, "profile validation failed")
+			}
 			cmd.Printf("Using profile %s.\n", usrProfile.ProfilePath)
 			cmd.Println(`Remember to load stack environment variables using 'eval "$(elastic-package stack shellinit)"'.`)
 
 			err = stack.BootUp(stack.Options{
-				DaemonMode:   daemonMode,
-				StackVersion: stackVersion,
-				Services:     services,
-				Profile:      usrProfile,
+				DaemonMode:       daemonMode,
+				StackVersion:     stackVersion,
+				Services:         services,
+				OverrideFiles:    overrideFiles,
+				NoPull:           noPull,
+				ExtraEnv:         extraEnv,
+				RemoteDockerHost: dockerHost,
+				Wait:             wait,
+				WaitTimeout:      waitTimeout,
+				Profile:          usrProfile,
 			})
 			if err != nil {
 				return errors.Wrap(err, "booting up the stack failed")
@@ -109,6 +164,11 @@ func setupStackCommand() *cobraext.Command {
 	upCommand.Flags().StringSliceP(cobraext.StackServicesFlagName, "s", nil,
 		fmt.Sprintf(cobraext.StackServicesFlagDescription, strings.Join(availableServicesAsList(), ",")))
 	upCommand.Flags().StringP(cobraext.StackVersionFlagName, "", install.DefaultStackVersion, cobraext.StackVersionFlagDescription)
+	upCommand.Flags().StringSliceP(cobraext.StackOverrideFileFlagName, "", nil, cobraext.StackOverrideFileFlagDescription)
+	upCommand.Flags().StringArrayP(cobraext.StackSetEnvFlagName, "", nil, cobraext.StackSetEnvFlagDescription)
+	upCommand.Flags().BoolP(cobraext.StackWaitFlagName, "", false, cobraext.StackWaitFlagDescription)
+	upCommand.Flags().DurationP(cobraext.StackWaitTimeoutFlagName, "", 5*time.Minute, cobraext.StackWaitTimeoutFlagDescription)
+	upCommand.Flags().BoolP(cobraext.StackNoPullFlagName, "", false, cobraext.StackNoPullFlagDescription)
 
 	downCommand := &cobra.Command{
 		Use:   "down",
@@ -134,9 +194,18 @@ func setupStackCommand() *cobraext.Command {
 				return errors.Wrap(err, "error loading profile")
 			}
 
-			err = stack.TearDown(stack.Options{
-				Profile: usrProfile,
-			})
+			preserveVolumes, err := cmd.Flags().GetBool(cobraext.StackPreserveVolumesFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackPreserveVolumesFlagName)
+			}
+
+			options, err := runningStackOptions(cmd, usrProfile)
+			if err != nil {
+				return err
+			}
+			options.PreserveVolumes = preserveVolumes
+
+			err = stack.TearDown(options)
 			if err != nil {
 				return errors.Wrap(err, "tearing down the stack failed")
 			}
@@ -146,6 +215,8 @@ func setupStackCommand() *cobraext.Command {
 		},
 	}
 
+	downCommand.Flags().Bool(cobraext.StackPreserveVolumesFlagName, false, cobraext.StackPreserveVolumesFlagDescription)
+
 	updateCommand := &cobra.Command{
 		Use:   "update",
 		Short: "Update the stack to the most recent versions",
@@ -167,10 +238,13 @@ func setupStackCommand() *cobraext.Command {
 				return cobraext.FlagParsingError(err, cobraext.StackVersionFlagName)
 			}
 
-			err = stack.Update(stack.Options{
-				StackVersion: stackVersion,
-				Profile:      profile,
-			})
+			options, err := runningStackOptions(cmd, profile)
+			if err != nil {
+				return err
+			}
+			options.StackVersion = stackVersion
+
+			err = stack.Update(options)
 			if err != nil {
 				return errors.Wrap(err, "failed updating the stack images")
 			}
@@ -195,7 +269,12 @@ func setupStackCommand() *cobraext.Command {
 				return errors.Wrap(err, "error loading profile")
 			}
 
-			shell, err := stack.ShellInit(profile)
+			options, err := runningStackOptions(cmd, profile)
+			if err != nil {
+				return err
+			}
+
+			shell, err := stack.ShellInit(options)
 			if err != nil {
 				return errors.Wrap(err, "shellinit failed")
 			}
@@ -223,9 +302,15 @@ func setupStackCommand() *cobraext.Command {
 				return errors.Wrap(err, "error loading profile")
 			}
 
+			dockerHost, err := dockerHostFlag(cmd)
+			if err != nil {
+				return err
+			}
+
 			target, err := stack.Dump(stack.DumpOptions{
-				Output:  output,
-				Profile: profile,
+				Output:           output,
+				RemoteDockerHost: dockerHost,
+				Profile:          profile,
 			})
 			if err != nil {
 				return errors.Wrap(err, "dump failed")
@@ -239,22 +324,462 @@ func setupStackCommand() *cobraext.Command {
 	}
 	dumpCommand.Flags().StringP(cobraext.StackDumpOutputFlagName, "", "elastic-stack-dump", cobraext.StackDumpOutputFlagDescription)
 
+	restartCommand := &cobra.Command{
+		Use:   "restart [services...]",
+		Short: "Restart the stack services",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Println("Restart the Elastic stack services")
+
+			services := args
+			common.TrimStringSlice(services)
+
+			err := validateServicesFlag(services)
+			if err != nil {
+				return errors.Wrap(err, "validating services failed")
+			}
+
+			profileName, err := cmd.Flags().GetString(cobraext.ProfileFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.ProfileFlagName)
+			}
+
+			profile, err := profile.LoadProfile(profileName)
+			if err != nil {
+				return errors.Wrap(err, "error loading profile")
+			}
+
+			options, err := runningStackOptions(cmd, profile)
+			if err != nil {
+				return err
+			}
+
+			err = stack.Restart(options, services...)
+			if err != nil {
+				return errors.Wrap(err, "restarting the stack services failed")
+			}
+
+			cmd.Println("Done")
+			return nil
+		},
+	}
+
+	benchmarkCommand := &cobra.Command{
+		Use:   "benchmark",
+		Short: "Measure indexing throughput of the stack",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			docs, err := cmd.Flags().GetInt(cobraext.StackBenchmarkDocsFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackBenchmarkDocsFlagName)
+			}
+
+			batchSize, err := cmd.Flags().GetInt(cobraext.StackBenchmarkBatchSizeFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackBenchmarkBatchSizeFlagName)
+			}
+
+			profileName, err := cmd.Flags().GetString(cobraext.ProfileFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.ProfileFlagName)
+			}
+
+			profile, err := profile.LoadProfile(profileName)
+			if err != nil {
+				return errors.Wrap(err, "error loading profile")
+			}
+
+			options, err := runningStackOptions(cmd, profile)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Index %d documents into Elasticsearch\n", docs)
+			result, err := stack.Benchmark(options, docs, batchSize)
+			if err != nil {
+				return errors.Wrap(err, "benchmarking the stack failed")
+			}
+
+			cmd.Printf("Indexed %d documents in %d ms (%.0f docs/s)\n", result.TotalDocs, result.DurationMs, result.DocsPerSecond)
+			return nil
+		},
+	}
+	benchmarkCommand.Flags().IntP(cobraext.StackBenchmarkDocsFlagName, "", 10000, cobraext.StackBenchmarkDocsFlagDescription)
+	benchmarkCommand.Flags().IntP(cobraext.StackBenchmarkBatchSizeFlagName, "", 1000, cobraext.StackBenchmarkBatchSizeFlagDescription)
+
+	exportCommand := &cobra.Command{
+		Use:   "export",
+		Short: "Export description of the running stack",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, err := cmd.Flags().GetString(cobraext.StackExportOutputFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackExportOutputFlagName)
+			}
+
+			profileName, err := cmd.Flags().GetString(cobraext.ProfileFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.ProfileFlagName)
+			}
+
+			profile, err := profile.LoadProfile(profileName)
+			if err != nil {
+				return errors.Wrap(err, "error loading profile")
+			}
+
+			options, err := runningStackOptions(cmd, profile)
+			if err != nil {
+				return err
+			}
+
+			err = stack.Export(options, output)
+			if err != nil {
+				return errors.Wrap(err, "exporting the stack failed")
+			}
+
+			cmd.Printf("Stack description has been written to %s\n", output)
+			return nil
+		},
+	}
+	exportCommand.Flags().StringP(cobraext.StackExportOutputFlagName, "", "elastic-stack.yml", cobraext.StackExportOutputFlagDescription)
+
+	logsCommand := &cobra.Command{
+		Use:   "logs [services...]",
+		Short: "Show logs of the stack services",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			services := args
+			common.TrimStringSlice(services)
+
+			err := validateServicesFlag(services)
+			if err != nil {
+				return errors.Wrap(err, "validating services failed")
+			}
+
+			follow, err := cmd.Flags().GetBool(cobraext.StackLogsFollowFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackLogsFollowFlagName)
+			}
+
+			sinceDuration, err := cmd.Flags().GetDuration(cobraext.StackLogsSinceFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackLogsSinceFlagName)
+			}
+			var since time.Time
+			if sinceDuration > 0 {
+				since = time.Now().Add(-sinceDuration)
+			}
+
+			format, err := cmd.Flags().GetString(cobraext.StackLogsFormatFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackLogsFormatFlagName)
+			}
+
+			profileName, err := cmd.Flags().GetString(cobraext.ProfileFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.ProfileFlagName)
+			}
+
+			profile, err := profile.LoadProfile(profileName)
+			if err != nil {
+				return errors.Wrap(err, "error loading profile")
+			}
+
+			options, err := runningStackOptions(cmd, profile)
+			if err != nil {
+				return err
+			}
+
+			err = stack.Logs(options, follow, since, format, services...)
+			if err != nil {
+				return errors.Wrap(err, "showing logs of the stack services failed")
+			}
+			return nil
+		},
+	}
+	logsCommand.Flags().BoolP(cobraext.StackLogsFollowFlagName, "f", false, cobraext.StackLogsFollowFlagDescription)
+	logsCommand.Flags().DurationP(cobraext.StackLogsSinceFlagName, "", 0, cobraext.StackLogsSinceFlagDescription)
+	logsCommand.Flags().StringP(cobraext.StackLogsFormatFlagName, "", stack.LogsTextFormat, cobraext.StackLogsFormatFlagDescription)
+
+	pruneCommand := &cobra.Command{
+		Use:   "prune",
+		Short: "Reclaim disk space used by Docker",
+		Long:  "Remove stopped containers, dangling images and, optionally, unused volumes.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pruneContainers, err := cmd.Flags().GetBool(cobraext.StackPruneContainersFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackPruneContainersFlagName)
+			}
+
+			pruneImages, err := cmd.Flags().GetBool(cobraext.StackPruneImagesFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackPruneImagesFlagName)
+			}
+
+			pruneAllImages, err := cmd.Flags().GetBool(cobraext.StackPruneAllImagesFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackPruneAllImagesFlagName)
+			}
+
+			pruneVolumes, err := cmd.Flags().GetBool(cobraext.StackPruneVolumesFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackPruneVolumesFlagName)
+			}
+
+			options, err := runningStackOptions(cmd, nil)
+			if err != nil {
+				return err
+			}
+			ctx := docker.WithHost(context.Background(), options.RemoteDockerHost)
+
+			cmd.Println("Prune unused Docker resources")
+			reclaimed, err := docker.PruneWithContext(ctx, pruneContainers, false, pruneVolumes)
+			if err != nil {
+				return errors.Wrap(err, "pruning Docker resources failed")
+			}
+
+			if pruneImages || pruneAllImages {
+				reclaimedByImages, err := docker.ImagePruneWithContext(ctx, pruneAllImages)
+				if err != nil {
+					return errors.Wrap(err, "pruning Docker images failed")
+				}
+				reclaimed += reclaimedByImages
+			}
+
+			cmd.Printf("Total reclaimed space: %.2f MB\n", float64(reclaimed)/1e6)
+			return nil
+		},
+	}
+	pruneCommand.Flags().BoolP(cobraext.StackPruneContainersFlagName, "", true, cobraext.StackPruneContainersFlagDescription)
+	pruneCommand.Flags().BoolP(cobraext.StackPruneImagesFlagName, "", true, cobraext.StackPruneImagesFlagDescription)
+	pruneCommand.Flags().BoolP(cobraext.StackPruneAllImagesFlagName, "", false, cobraext.StackPruneAllImagesFlagDescription)
+	pruneCommand.Flags().BoolP(cobraext.StackPruneVolumesFlagName, "", false, cobraext.StackPruneVolumesFlagDescription)
+
+	scaleCommand := &cobra.Command{
+		Use:   "scale",
+		Short: "Scale the stack service",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := cmd.Flags().GetString(cobraext.StackScaleServiceFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackScaleServiceFlagName)
+			}
+
+			replicas, err := cmd.Flags().GetInt(cobraext.StackScaleReplicasFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackScaleReplicasFlagName)
+			}
+
+			profileName, err := cmd.Flags().GetString(cobraext.ProfileFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.ProfileFlagName)
+			}
+
+			profile, err := profile.LoadProfile(profileName)
+			if err != nil {
+				return errors.Wrap(err, "error loading profile")
+			}
+
+			options, err := runningStackOptions(cmd, profile)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Scale the %s service to %d replicas\n", service, replicas)
+			err = stack.Scale(options, service, replicas)
+			if err != nil {
+				return errors.Wrap(err, "scaling the stack service failed")
+			}
+
+			cmd.Println("Done")
+			return nil
+		},
+	}
+	scaleCommand.Flags().StringP(cobraext.StackScaleServiceFlagName, "", "", cobraext.StackScaleServiceFlagDescription)
+	scaleCommand.Flags().IntP(cobraext.StackScaleReplicasFlagName, "", 1, cobraext.StackScaleReplicasFlagDescription)
+	scaleCommand.MarkFlagRequired(cobraext.StackScaleServiceFlagName)
+	scaleCommand.MarkFlagRequired(cobraext.StackScaleReplicasFlagName)
+
+	statusCommand := &cobra.Command{
+		Use:   "status",
+		Short: "Show status of the stack services",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profileName, err := cmd.Flags().GetString(cobraext.ProfileFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.ProfileFlagName)
+			}
+
+			profile, err := profile.LoadProfile(profileName)
+			if err != nil {
+				return errors.Wrap(err, "error loading profile")
+			}
+
+			options, err := runningStackOptions(cmd, profile)
+			if err != nil {
+				return err
+			}
+
+			servicesStatus, err := stack.Status(options)
+			if err != nil {
+				return errors.Wrap(err, "failed getting stack status")
+			}
+
+			if len(servicesStatus) == 0 {
+				cmd.Println("There are no Elastic stack services running.")
+				return nil
+			}
+			printStackStatus(servicesStatus)
+			return nil
+		},
+	}
+
+	imagesSaveCommand := &cobra.Command{
+		Use:   "save",
+		Short: "Save the stack images to archives",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stackVersion, err := cmd.Flags().GetString(cobraext.StackVersionFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackVersionFlagName)
+			}
+
+			output, err := cmd.Flags().GetString(cobraext.StackImagesOutputFlagName)
+			if err != nil {
+				return cobraext.FlagParsingError(err, cobraext.StackImagesOutputFlagName)
+			}
+
+			options, err := runningStackOptions(cmd, nil)
+			if err != nil {
+				return err
+			}
+			options.StackVersion = stackVersion
+
+			cmd.Printf("Save images of the Elastic stack %s\n", stackVersion)
+			paths, err := stack.SaveImages(options, output)
+			if err != nil {
+				return errors.Wrap(err, "saving stack images failed")
+			}
+
+			for _, path := range paths {
+				cmd.Printf("- %s\n", path)
+			}
+			cmd.Println("Done")
+			return nil
+		},
+	}
+	imagesSaveCommand.Flags().StringP(cobraext.StackVersionFlagName, "", install.DefaultStackVersion, cobraext.StackVersionFlagDescription)
+	imagesSaveCommand.Flags().StringP(cobraext.StackImagesOutputFlagName, "", "stack-images", cobraext.StackImagesOutputFlagDescription)
+
+	imagesLoadCommand := &cobra.Command{
+		Use:   "load [archives...]",
+		Short: "Load the stack images from archives",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options, err := runningStackOptions(cmd, nil)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println("Load images of the Elastic stack")
+			err = stack.LoadImages(options, args...)
+			if err != nil {
+				return errors.Wrap(err, "loading stack images failed")
+			}
+
+			cmd.Println("Done")
+			return nil
+		},
+	}
+
+	imagesCommand := &cobra.Command{
+		Use:   "images",
+		Short: "Save and load the stack images for offline environments",
+	}
+	imagesCommand.AddCommand(imagesSaveCommand, imagesLoadCommand)
+
 	cmd := &cobra.Command{
 		Use:   "stack",
 		Short: "Manage the Elastic stack",
 		Long:  stackLongDescription,
 	}
 	cmd.PersistentFlags().StringP(cobraext.ProfileFlagName, "p", lookupEnv(), fmt.Sprintf(cobraext.ProfileFlagDescription, profileNameEnvVar))
+	cmd.PersistentFlags().StringP(cobraext.StackDockerHostFlagName, "", "", cobraext.StackDockerHostFlagDescription)
 	cmd.AddCommand(
 		upCommand,
 		downCommand,
 		updateCommand,
 		shellInitCommand,
-		dumpCommand)
+		dumpCommand,
+		restartCommand,
+		benchmarkCommand,
+		exportCommand,
+		imagesCommand,
+		logsCommand,
+		pruneCommand,
+		scaleCommand,
+		statusCommand)
 
 	return cobraext.NewCommand(cmd, cobraext.ContextGlobal)
 }
 
+// runningStackOptions function returns options of the running stack. The Docker daemon selected with
+// the --docker-host flag takes precedence over the one the stack has been booted up with.
+func runningStackOptions(cmd *cobra.Command, usrProfile *profile.Profile) (stack.Options, error) {
+	options, err := stack.RunningOptions(usrProfile)
+	if err != nil {
+		return stack.Options{}, errors.Wrap(err, "can't read options of the running stack")
+	}
+
+	dockerHost, err := dockerHostFlag(cmd)
+	if err != nil {
+		return stack.Options{}, err
+	}
+	if dockerHost != "" {
+		options.RemoteDockerHost = dockerHost
+	}
+	return options, nil
+}
+
+// dockerHostFlag function returns the validated address of the Docker daemon selected with the --docker-host flag.
+func dockerHostFlag(cmd *cobra.Command) (string, error) {
+	dockerHost, err := cmd.Flags().GetString(cobraext.StackDockerHostFlagName)
+	if err != nil {
+		return "", cobraext.FlagParsingError(err, cobraext.StackDockerHostFlagName)
+	}
+	if dockerHost == "" {
+		return "", nil
+	}
+
+	err = stack.ValidateDockerHost(dockerHost)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid --%s flag", cobraext.StackDockerHostFlagName)
+	}
+	return dockerHost, nil
+}
+
+func printStackStatus(servicesStatus []stack.ServiceStatus) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Service", "Image", "Status", "Health", "Exit Code"})
+	table.SetHeaderColor(
+		twColor(tablewriter.Colors{tablewriter.Bold}),
+		twColor(tablewriter.Colors{tablewriter.Bold}),
+		twColor(tablewriter.Colors{tablewriter.Bold}),
+		twColor(tablewriter.Colors{tablewriter.Bold}),
+		twColor(tablewriter.Colors{tablewriter.Bold}),
+	)
+	table.SetColumnColor(
+		twColor(tablewriter.Colors{tablewriter.Bold, tablewriter.FgCyanColor}),
+		tablewriter.Colors{},
+		tablewriter.Colors{},
+		tablewriter.Colors{},
+		tablewriter.Colors{},
+	)
+	table.SetAutoMergeCells(false)
+
+	for _, service := range servicesStatus {
+		health := service.Health
+		if health == "" {
+			health = "-"
+		}
+		table.Append([]string{service.Name, service.Image, service.Status, health, strconv.Itoa(service.ExitCode)})
+	}
+	table.Render()
+}
+
 func availableServicesAsList() []string {
 	available := make([]string, len(availableServices))
 	i := 0
@@ -281,3 +806,17 @@ func validateServicesFlag(services []string) error {
 	}
 	return nil
 }
+
+// parseSetEnvFlag converts KEY=VALUE pairs into a map, later values override earlier ones.
+func parseSetEnvFlag(values []string) (map[string]string, error) {
+	envs := make(map[string]string, len(values))
+	for _, value := range values {
+		kv := strings.SplitN(value, "=", 2)
+		key := strings.TrimSpace(kv[0])
+		if len(kv) != 2 || key == "" {
+			return nil, fmt.Errorf("expected KEY=VALUE, got %q", value)
+		}
+		envs[key] = kv[1]
+	}
+	return envs, nil
+}
@@ -47,3 +47,15 @@ func TestValidateServicesFlag(t *testing.T) {
 	}
 
 }
+
+func TestParseSetEnvFlag(t *testing.T) {
+	envs, err := parseSetEnvFlag([]string{"ES_NODE_ROLES=master,data", "EMPTY=", "ES_NODE_ROLES=data"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"ES_NODE_ROLES": "data", "EMPTY": ""}, envs)
+
+	_, err = parseSetEnvFlag([]string{"ES_NODE_ROLES"})
+	require.Error(t, err)
+
+	_, err = parseSetEnvFlag([]string{"=data"})
+	require.Error(t, err)
+}
@@ -4,9 +4,40 @@
 
 package cobraext
 
-import "github.com/pkg/errors"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
 
 // FlagParsingError method wraps the original error with parsing error.
 func FlagParsingError(err error, flagName string) error {
 	return errors.Wrapf(err, "error parsing --%s flag", flagName)
 }
+
+// RequireOneOf method returns a validator checking that exactly one of the mutually exclusive flags is set.
+// It can be used as the Args validator of the command, so it's evaluated before RunE.
+func RequireOneOf(flags ...string) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		var set []string
+		for _, flag := range flags {
+			if cmd.Flags().Changed(flag) {
+				set = append(set, "--"+flag)
+			}
+		}
+		if len(set) == 1 {
+			return nil
+		}
+
+		names := make([]string, len(flags))
+		for i, flag := range flags {
+			names[i] = "--" + flag
+		}
+		if len(set) == 0 {
+			return fmt.Errorf("one of the flags is required: %s", strings.Join(names, ", "))
+		}
+		return fmt.Errorf("only one of the flags can be set: %s (got: %s)", strings.Join(names, ", "), strings.Join(set, ", "))
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cobraext
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireOneOf(t *testing.T) {
+	cases := []struct {
+		args     []string
+		expected string
+	}{
+		{args: []string{"--file", "a.yml"}},
+		{args: []string{"--url", "http://localhost"}},
+		{args: nil, expected: "one of the flags is required: --file, --url"},
+		{args: []string{"--file", "a.yml", "--url", "http://localhost"}, expected: "only one of the flags can be set: --file, --url (got: --file, --url)"},
+	}
+
+	for _, c := range cases {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("file", "", "")
+		cmd.Flags().String("url", "", "")
+		require.NoError(t, cmd.ParseFlags(c.args))
+
+		err := RequireOneOf("file", "url")(cmd, nil)
+		if c.expected == "" {
+			assert.NoError(t, err, "args: %v", c.args)
+		} else {
+			assert.EqualError(t, err, c.expected, "args: %v", c.args)
+		}
+	}
+}
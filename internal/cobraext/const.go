@@ -51,12 +51,21 @@ const (
 	ProfileFlagName        = "profile"
 	ProfileFlagDescription = "select a profile to use for the stack configuration. Can also be set with %s"
 
+	ProfileFromEnvFlagName        = "profile-from-env"
+	ProfileFromEnvFlagDescription = "read the profile name from the given environment variable, the default profile is used if it's not set"
+
 	ProfileFromFlagName        = "from"
 	ProfileFromFlagDescription = "copy profile from the specified existing profile"
 
+	ProfileOverwriteFlagName        = "overwrite"
+	ProfileOverwriteFlagDescription = "replace the destination profile if it already exists"
+
 	ProfileFormatFlagName        = "format"
 	ProfileFormatFlagDescription = "format of the profiles list (table | json)"
 
+	LogFormatFlagName        = "log-format"
+	LogFormatFlagDescription = "format of log lines (text | json)"
+
 	NewestOnlyFlagName        = "newest-only"
 	NewestOnlyFlagDescription = "promote newest packages and remove old ones"
 
@@ -87,6 +96,66 @@ const (
 	StackVersionFlagName        = "version"
 	StackVersionFlagDescription = "stack version"
 
+	StackPreserveVolumesFlagName        = "preserve-volumes"
+	StackPreserveVolumesFlagDescription = "keep data volumes of the stack containers"
+
+	StackBenchmarkDocsFlagName        = "docs"
+	StackBenchmarkDocsFlagDescription = "number of documents to index"
+
+	StackBenchmarkBatchSizeFlagName        = "batch-size"
+	StackBenchmarkBatchSizeFlagDescription = "number of documents indexed in a single bulk request"
+
+	StackDockerHostFlagName        = "docker-host"
+	StackDockerHostFlagDescription = "address of the remote Docker daemon running the stack (e.g. tcp://10.0.0.5:2376), by default the one the stack has been booted up with"
+
+	StackExportOutputFlagName        = "output"
+	StackExportOutputFlagDescription = "output file for the stack description"
+
+	StackImagesOutputFlagName        = "output"
+	StackImagesOutputFlagDescription = "directory for the image archives"
+
+	StackLogsFollowFlagName        = "follow"
+	StackLogsFollowFlagDescription = "keep streaming new log entries"
+
+	StackLogsFormatFlagName        = "format"
+	StackLogsFormatFlagDescription = "format of log lines (text | json | raw)"
+
+	StackLogsSinceFlagName        = "since"
+	StackLogsSinceFlagDescription = "show only log entries newer than the given duration (e.g. 10m)"
+
+	StackNoPullFlagName        = "no-pull"
+	StackNoPullFlagDescription = "don't pull images, use locally cached images instead"
+
+	StackOverrideFileFlagName        = "override-file"
+	StackOverrideFileFlagDescription = "Docker Compose file overriding the stack configuration, can be repeated"
+
+	StackSetEnvFlagName        = "set"
+	StackSetEnvFlagDescription = "set environment variable for the stack services (KEY=VALUE), can be repeated"
+
+	StackPruneContainersFlagName        = "containers"
+	StackPruneContainersFlagDescription = "remove stopped containers"
+
+	StackPruneImagesFlagName        = "images"
+	StackPruneImagesFlagDescription = "remove dangling images"
+
+	StackPruneAllImagesFlagName        = "all-images"
+	StackPruneAllImagesFlagDescription = "remove all images not used by any container, not only dangling ones"
+
+	StackPruneVolumesFlagName        = "volumes"
+	StackPruneVolumesFlagDescription = "remove volumes not used by any container"
+
+	StackWaitFlagName        = "wait"
+	StackWaitFlagDescription = "wait until the stack services are healthy (requires daemon mode)"
+
+	StackWaitTimeoutFlagName        = "wait-timeout"
+	StackWaitTimeoutFlagDescription = "maximum time to wait for the stack services to be healthy"
+
+	StackScaleServiceFlagName        = "service"
+	StackScaleServiceFlagDescription = "stack service to scale"
+
+	StackScaleReplicasFlagName        = "replicas"
+	StackScaleReplicasFlagDescription = "number of service containers"
+
 	StackDumpOutputFlagName        = "output"
 	StackDumpOutputFlagDescription = "output location for the stack dump"
 
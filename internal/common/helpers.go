@@ -13,6 +13,27 @@ func TrimStringSlice(slice []string) {
 	}
 }
 
+// TrimStringMap returns a copy of the map with whitespace removed from the beginning and end of keys and values.
+func TrimStringMap(m map[string]string) map[string]string {
+	trimmed := make(map[string]string, len(m))
+	for key, value := range m {
+		trimmed[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return trimmed
+}
+
+// TrimStringSliceMap returns a copy of the map with whitespace removed from the beginning and end of keys
+// and contents of values.
+func TrimStringSliceMap(m map[string][]string) map[string][]string {
+	trimmed := make(map[string][]string, len(m))
+	for key, values := range m {
+		trimmedValues := append([]string(nil), values...)
+		TrimStringSlice(trimmedValues)
+		trimmed[strings.TrimSpace(key)] = trimmedValues
+	}
+	return trimmed
+}
+
 // StringSliceContains checks if the slice contains the given string.
 func StringSliceContains(slice []string, s string) bool {
 	for i := range slice {
@@ -19,6 +19,21 @@ func TestTrimStringSlice(t *testing.T) {
 	require.Equal(t, expected, strs)
 }
 
+func TestTrimStringMap(t *testing.T) {
+	m := map[string]string{" foo": "bar ", "baz\t": "\tqux"}
+	expected := map[string]string{"foo": "bar", "baz": "qux"}
+
+	require.Equal(t, expected, TrimStringMap(m))
+}
+
+func TestTrimStringSliceMap(t *testing.T) {
+	m := map[string][]string{" foo ": {" bar", "baz "}}
+	expected := map[string][]string{"foo": {"bar", "baz"}}
+
+	require.Equal(t, expected, TrimStringSliceMap(m))
+	assert.Equal(t, []string{" bar", "baz "}, m[" foo "], "original map shouldn't be modified")
+}
+
 func TestStringSliceContains(t *testing.T) {
 	cases := []struct {
 		slice    []string
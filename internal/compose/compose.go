@@ -194,6 +194,20 @@ func (p *Project) Down(opts CommandOptions) error {
 	return nil
 }
 
+// Restart restarts services of a Docker Compose project.
+func (p *Project) Restart(opts CommandOptions) error {
+	args := p.baseArgs()
+	args = append(args, "restart")
+	args = append(args, opts.ExtraArgs...)
+	args = append(args, opts.Services...)
+
+	if err := p.runDockerComposeCmd(dockerComposeOptions{args: args, env: opts.Env}); err != nil {
+		return errors.Wrap(err, "running Docker Compose restart command failed")
+	}
+
+	return nil
+}
+
 // Build builds a Docker Compose project.
 func (p *Project) Build(opts CommandOptions) error {
 	args := p.baseArgs()
@@ -270,22 +284,37 @@ func (p *Project) Logs(opts CommandOptions) ([]byte, error) {
 	return b.Bytes(), nil
 }
 
-// WaitForHealthy method waits until all containers are healthy.
-func (p *Project) WaitForHealthy(opts CommandOptions) error {
-	// Read container IDs
+// ContainerIDs method returns IDs of containers created for the Docker Compose project.
+func (p *Project) ContainerIDs(opts CommandOptions) ([]string, error) {
 	args := p.baseArgs()
 	args = append(args, "ps")
 	args = append(args, "-q")
+	args = append(args, opts.ExtraArgs...)
+	args = append(args, opts.Services...)
 
 	var b bytes.Buffer
 	if err := p.runDockerComposeCmd(dockerComposeOptions{args: args, env: opts.Env, stdout: &b}); err != nil {
+		return nil, errors.Wrap(err, "running Docker Compose ps command failed")
+	}
+
+	output := strings.TrimSpace(b.String())
+	if output == "" {
+		return nil, nil
+	}
+	return strings.Split(output, "\n"), nil
+}
+
+// WaitForHealthy method waits until all containers are healthy.
+func (p *Project) WaitForHealthy(opts CommandOptions) error {
+	// Read container IDs
+	containerIDs, err := p.ContainerIDs(CommandOptions{Env: opts.Env})
+	if err != nil {
 		return err
 	}
 
 	startTime := time.Now()
 	timeout := startTime.Add(waitForHealthyTimeout)
 
-	containerIDs := strings.Split(strings.TrimSpace(b.String()), "\n")
 	for {
 		if time.Now().After(timeout) {
 			return errors.New("timeout waiting for healthy container")
@@ -308,12 +337,12 @@ func (p *Project) WaitForHealthy(opts CommandOptions) error {
 			logger.Debugf("Container status: %s", containerDescription.String())
 
 			// No healthcheck defined for service
-			if containerDescription.State.Status == "running" && containerDescription.State.Health == nil {
+			if containerDescription.IsRunning() && containerDescription.State.Health == nil {
 				continue
 			}
 
 			// Service is up and running and it's healthy
-			if containerDescription.State.Status == "running" && containerDescription.State.Health.Status == "healthy" {
+			if containerDescription.IsRunning() && containerDescription.IsHealthy() {
 				continue
 			}
 
@@ -323,7 +352,7 @@ func (p *Project) WaitForHealthy(opts CommandOptions) error {
 			}
 
 			// Container exited with code > 0
-			if containerDescription.State.Status == "exited" && containerDescription.State.ExitCode > 0 {
+			if containerDescription.IsFailed() {
 				return fmt.Errorf("container (ID: %s) exited with code %d", containerDescription.ID, containerDescription.State.ExitCode)
 			}
 
@@ -400,3 +429,11 @@ func (p *Project) ContainerName(serviceName string) string {
 	}
 	return fmt.Sprintf("%s-%s-1", p.name, serviceName)
 }
+
+// ImageName method returns the name of the image built for the service, if the service doesn't set it.
+func (p *Project) ImageName(serviceName string) string {
+	if p.dockerComposeV1 {
+		return fmt.Sprintf("%s_%s", p.name, serviceName)
+	}
+	return fmt.Sprintf("%s-%s", p.name, serviceName)
+}
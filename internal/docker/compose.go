@@ -0,0 +1,92 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/elastic-package/internal/logger"
+)
+
+// ComposeOptions defines options of the Docker Compose project started with ComposeUp.
+type ComposeOptions struct {
+	// ProjectName overrides the project name derived from the directory of the Compose file.
+	ProjectName string
+
+	// Env contains additional environment variables in the KEY=VALUE form, used for variable substitution.
+	Env []string
+
+	// Services limits the command to the selected services, all services are started if empty.
+	Services []string
+}
+
+// ComposeUp function starts services of the Compose file in the background with "docker compose up -d".
+func ComposeUp(composeFile string, opts ComposeOptions) error {
+	return ComposeUpWithContext(context.Background(), composeFile, opts)
+}
+
+// ComposeUpWithContext function starts services of the Compose file like ComposeUp, using the Docker daemon selected with WithHost.
+func ComposeUpWithContext(ctx context.Context, composeFile string, opts ComposeOptions) error {
+	args := composeArgs(composeFile, opts.ProjectName)
+	args = append(args, "up", "-d")
+	args = append(args, opts.Services...)
+
+	cmd := dockerCommand(ctx, args...)
+	appendEnv(cmd, opts.Env...)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	if logger.IsDebugMode() {
+		cmd.Stdout = os.Stdout
+	}
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not start services of %s (stderr=%q)", composeFile, errOutput.String())
+	}
+	return nil
+}
+
+// ComposeDown function stops and removes containers and networks of the Compose file with "docker compose down".
+// Volumes are removed too if removeVolumes is set. The default project name is used, so projects started
+// with a custom ComposeOptions.ProjectName are not matched.
+func ComposeDown(composeFile string, removeVolumes bool) error {
+	return ComposeDownWithContext(context.Background(), composeFile, removeVolumes)
+}
+
+// ComposeDownWithContext function takes down services of the Compose file like ComposeDown, using the Docker daemon selected with WithHost.
+func ComposeDownWithContext(ctx context.Context, composeFile string, removeVolumes bool) error {
+	args := composeArgs(composeFile, "")
+	args = append(args, "down", "--remove-orphans")
+	if removeVolumes {
+		args = append(args, "--volumes")
+	}
+
+	cmd := dockerCommand(ctx, args...)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	if logger.IsDebugMode() {
+		cmd.Stdout = os.Stdout
+	}
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not take down services of %s (stderr=%q)", composeFile, errOutput.String())
+	}
+	return nil
+}
+
+func composeArgs(composeFile, projectName string) []string {
+	args := []string{"compose", "-f", composeFile}
+	if projectName != "" {
+		args = append(args, "-p", projectName)
+	}
+	return args
+}
@@ -6,16 +6,20 @@ package docker
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/elastic/elastic-package/internal/logger"
+	"github.com/elastic/elastic-package/internal/signal"
 )
 
 // NetworkDescription describes the Docker network and connected Docker containers.
@@ -25,9 +29,45 @@ type NetworkDescription struct {
 	}
 }
 
+// ContainerNames function returns sorted names of containers connected to the network.
+func (n *NetworkDescription) ContainerNames() []string {
+	names := make([]string, 0, len(n.Containers))
+	for _, c := range n.Containers {
+		names = append(names, c.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasContainer function checks if the container with the given name is connected to the network.
+func (n *NetworkDescription) HasContainer(containerName string) bool {
+	for _, c := range n.Containers {
+		if c.Name == containerName {
+			return true
+		}
+	}
+	return false
+}
+
 // ContainerDescription describes the Docker container.
 type ContainerDescription struct {
-	ID    string
+	ID     string
+	Name   string
+	Config struct {
+		Image  string
+		Labels map[string]string
+	}
+	Mounts []struct {
+		Type        string
+		Name        string
+		Source      string
+		Destination string
+	}
+	NetworkSettings struct {
+		Networks map[string]struct {
+			IPAddress string
+		}
+	}
 	State struct {
 		Status   string
 		ExitCode int
@@ -51,9 +91,48 @@ func (c *ContainerDescription) String() string {
 	return string(b)
 }
 
+// IsRunning function checks if the container is running.
+func (c *ContainerDescription) IsRunning() bool {
+	return c.State.Status == "running"
+}
+
+// IsFailed function checks if the container exited with non-zero exit code.
+func (c *ContainerDescription) IsFailed() bool {
+	return c.State.Status == "exited" && c.State.ExitCode != 0
+}
+
+// IsHealthy function checks if the container healthcheck reports it as healthy.
+func (c *ContainerDescription) IsHealthy() bool {
+	return c.State.Health != nil && c.State.Health.Status == "healthy"
+}
+
+// unmarshalJSONLines function decodes output of Docker commands formatted with "{{json .}}",
+// which prints a single JSON document per line, into the slice pointed by v.
+func unmarshalJSONLines(output []byte, v interface{}) error {
+	var docs []json.RawMessage
+	for _, line := range bytes.Split(bytes.TrimSpace(output), []byte{'\n'}) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		docs = append(docs, line)
+	}
+
+	list, err := json.Marshal(docs)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(list, v)
+}
+
 // Pull downloads the latest available revision of the image.
 func Pull(image string) error {
-	cmd := exec.Command("docker", "pull", image)
+	return PullWithContext(context.Background(), image)
+}
+
+// PullWithContext downloads the latest available revision of the image. The download is aborted
+// when the context is cancelled.
+func PullWithContext(ctx context.Context, image string) error {
+	cmd := dockerCommand(ctx, "pull", image)
 
 	if logger.IsDebugMode() {
 		cmd.Stdout = os.Stdout
@@ -68,9 +147,87 @@ func Pull(image string) error {
 	return nil
 }
 
+// PullWithRetry downloads the image, retrying up to maxAttempts times. The delay between
+// attempts starts at backoff and doubles after each failed attempt. The image is pulled at least once.
+func PullWithRetry(image string, maxAttempts int, backoff time.Duration) error {
+	return PullWithRetryWithContext(context.Background(), image, maxAttempts, backoff)
+}
+
+// PullWithRetryWithContext downloads the image like PullWithRetry, it stops retrying when the context is cancelled.
+func PullWithRetryWithContext(ctx context.Context, image string, maxAttempts int, backoff time.Duration) error {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err = PullWithContext(ctx, image)
+		if err == nil {
+			return nil
+		}
+		if attempt == maxAttempts {
+			break
+		}
+
+		logger.Debugf("pulling image %s failed (attempt %d/%d), retrying in %s: %v", image, attempt, maxAttempts, backoff, err)
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "pulling image %s cancelled", image)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return errors.Wrapf(err, "pulling image %s failed after %d attempts", image, maxAttempts)
+}
+
+// Tag creates the target image tag referring to the source image.
+func Tag(sourceImage, targetImage string) error {
+	return TagWithContext(context.Background(), sourceImage, targetImage)
+}
+
+// TagWithContext function creates the target image tag like Tag, using the Docker daemon selected with WithHost.
+func TagWithContext(ctx context.Context, sourceImage, targetImage string) error {
+	cmd := dockerCommand(ctx, "tag", sourceImage, targetImage)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not tag image %s as %s (stderr=%q)", sourceImage, targetImage, errOutput.String())
+	}
+	return nil
+}
+
+// Push uploads the image to the registry.
+func Push(image string) error {
+	return PushWithContext(context.Background(), image)
+}
+
+// PushWithContext uploads the image to the registry. The upload is aborted when the context is cancelled.
+func PushWithContext(ctx context.Context, image string) error {
+	cmd := dockerCommand(ctx, "push", image)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	if logger.IsDebugMode() {
+		cmd.Stdout = os.Stdout
+	}
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not push image %s (stderr=%q)", image, errOutput.String())
+	}
+	return nil
+}
+
 // ContainerID function returns the container ID for a given container name.
 func ContainerID(containerName string) (string, error) {
-	cmd := exec.Command("docker", "ps", "--filter", "name="+containerName, "--format", "{{.ID}}")
+	return ContainerIDWithContext(context.Background(), containerName)
+}
+
+// ContainerIDWithContext function returns the container ID like ContainerID, using the Docker daemon selected with WithHost.
+func ContainerIDWithContext(ctx context.Context, containerName string) (string, error) {
+	cmd := dockerCommand(ctx, "ps", "--filter", "name="+containerName, "--format", "{{.ID}}")
 	errOutput := new(bytes.Buffer)
 	cmd.Stderr = errOutput
 
@@ -86,9 +243,205 @@ func ContainerID(containerName string) (string, error) {
 	return string(containerIDs[0]), nil
 }
 
+// ContainerExists function checks if a container, running or stopped, with the given name exists.
+func ContainerExists(name string) (bool, error) {
+	return ContainerExistsWithContext(context.Background(), name)
+}
+
+// ContainerExistsWithContext function checks if the container exists like ContainerExists, using the Docker daemon selected with WithHost.
+func ContainerExistsWithContext(ctx context.Context, name string) (bool, error) {
+	cmd := dockerCommand(ctx, "ps", "-a", "--filter", "name="+name, "--format", "{{.Names}}")
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("output command: %s", cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return false, errors.Wrapf(err, "could not find \"%s\" container (stderr=%q)", name, errOutput.String())
+	}
+
+	// The name filter matches also partial names, so the exact name needs to be checked.
+	for _, containerName := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if containerName == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ContainerIDByLabel function returns IDs of running containers labeled with the given key and value.
+func ContainerIDByLabel(key, value string) ([]string, error) {
+	return ContainerIDByLabelWithContext(context.Background(), key, value)
+}
+
+// ContainerIDByLabelWithContext function returns IDs of labeled containers like ContainerIDByLabel, using the Docker daemon selected with WithHost.
+func ContainerIDByLabelWithContext(ctx context.Context, key, value string) ([]string, error) {
+	return containerIDsByLabel(ctx, false, key, value)
+}
+
+func containerIDsByLabel(ctx context.Context, all bool, key, value string) ([]string, error) {
+	args := []string{"ps", "--filter", fmt.Sprintf("label=%s=%s", key, value), "--format", "{{.ID}}"}
+	if all {
+		args = append(args, "--all")
+	}
+	cmd := dockerCommand(ctx, args...)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("output command: %s", cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not find containers with label %s=%s (stderr=%q)", key, value, errOutput.String())
+	}
+
+	output = bytes.TrimSpace(output)
+	if len(output) == 0 {
+		return nil, nil
+	}
+
+	var containerIDs []string
+	for _, id := range bytes.Split(output, []byte{'\n'}) {
+		containerIDs = append(containerIDs, string(id))
+	}
+	return containerIDs, nil
+}
+
+// ContainerSummary describes the container as listed by "docker ps".
+type ContainerSummary struct {
+	ID     string
+	Names  []string
+	Image  string
+	Status string
+	Labels map[string]string
+}
+
+// ListContainers function returns all containers, including stopped ones, matching the filters.
+// Filters are passed to "docker ps --filter" as key=value pairs, e.g. "label" with value
+// "com.docker.compose.project=elastic-package-stack".
+func ListContainers(filters map[string]string) ([]ContainerSummary, error) {
+	return ListContainersWithContext(context.Background(), filters)
+}
+
+// ListContainersWithContext function returns containers matching the filters like ListContainers, using the Docker daemon selected with WithHost.
+func ListContainersWithContext(ctx context.Context, filters map[string]string) ([]ContainerSummary, error) {
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	args := []string{"ps", "--all", "--no-trunc", "--format", "{{json .}}"}
+	for _, key := range keys {
+		args = append(args, "--filter", fmt.Sprintf("%s=%s", key, filters[key]))
+	}
+	cmd := dockerCommand(ctx, args...)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("output command: %s", cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not list containers (stderr=%q)", errOutput.String())
+	}
+
+	containers, err := parseContainerSummaries(output)
+	if err != nil {
+		return nil, err
+	}
+	if len(containers) == 0 {
+		return nil, nil
+	}
+
+	// Labels are read from container descriptions, "docker ps" joins them with commas,
+	// so label values containing commas can't be parsed from its output.
+	containerIDs := make([]string, len(containers))
+	for i, container := range containers {
+		containerIDs[i] = container.ID
+	}
+	descriptions, err := InspectContainersWithContext(ctx, containerIDs...)
+	if err != nil {
+		return nil, err
+	}
+	setContainerLabels(containers, descriptions)
+	return containers, nil
+}
+
+// parseContainerSummaries parses the "docker ps" output, which reports names as a comma-separated string.
+// Labels are not parsed, they are set with setContainerLabels.
+func parseContainerSummaries(output []byte) ([]ContainerSummary, error) {
+	var rawContainers []struct {
+		ID     string
+		Names  string
+		Image  string
+		Status string
+	}
+	err := unmarshalJSONLines(output, &rawContainers)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't unmarshal containers")
+	}
+
+	var containers []ContainerSummary
+	for _, raw := range rawContainers {
+		summary := ContainerSummary{
+			ID:     raw.ID,
+			Image:  raw.Image,
+			Status: raw.Status,
+			Labels: make(map[string]string),
+		}
+		if raw.Names != "" {
+			summary.Names = strings.Split(raw.Names, ",")
+		}
+		containers = append(containers, summary)
+	}
+	return containers, nil
+}
+
+// setContainerLabels sets labels of the listed containers from their descriptions.
+func setContainerLabels(containers []ContainerSummary, descriptions []ContainerDescription) {
+	labels := make(map[string]map[string]string, len(descriptions))
+	for _, description := range descriptions {
+		labels[description.ID] = description.Config.Labels
+	}
+	for i := range containers {
+		for key, value := range labels[containers[i].ID] {
+			containers[i].Labels[key] = value
+		}
+	}
+}
+
+// ContainerIP function returns the IP address of the container in the given network.
+func ContainerIP(containerID, networkName string) (string, error) {
+	return ContainerIPWithContext(context.Background(), containerID, networkName)
+}
+
+// ContainerIPWithContext function returns the IP address of the container like ContainerIP, using the Docker daemon selected with WithHost.
+func ContainerIPWithContext(ctx context.Context, containerID, networkName string) (string, error) {
+	format := fmt.Sprintf("{{with index .NetworkSettings.Networks %q}}{{.IPAddress}}{{end}}", networkName)
+	cmd := dockerCommand(ctx, "inspect", "--format", format, containerID)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("output command: %s", cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrapf(err, "could not inspect the container (stderr=%q)", errOutput.String())
+	}
+
+	ip := string(bytes.TrimSpace(output))
+	if ip == "" {
+		return "", fmt.Errorf("container %s has no IP address in network %s", containerID, networkName)
+	}
+	return ip, nil
+}
+
 // InspectNetwork function returns the network description for the selected network.
 func InspectNetwork(network string) ([]NetworkDescription, error) {
-	cmd := exec.Command("docker", "network", "inspect", network)
+	return InspectNetworkWithContext(context.Background(), network)
+}
+
+// InspectNetworkWithContext function returns the network description like InspectNetwork, using the Docker daemon selected with WithHost.
+func InspectNetworkWithContext(ctx context.Context, network string) ([]NetworkDescription, error) {
+	cmd := dockerCommand(ctx, "network", "inspect", network)
 	errOutput := new(bytes.Buffer)
 	cmd.Stderr = errOutput
 
@@ -106,9 +459,73 @@ func InspectNetwork(network string) ([]NetworkDescription, error) {
 	return networkDescriptions, nil
 }
 
+// NetworkSummary describes the Docker network as listed by "docker network ls".
+type NetworkSummary struct {
+	ID     string
+	Name   string
+	Driver string
+	Scope  string
+}
+
+// NetworkList function returns all Docker networks.
+func NetworkList() ([]NetworkSummary, error) {
+	return NetworkListWithContext(context.Background())
+}
+
+// NetworkListWithContext function returns all Docker networks like NetworkList, using the Docker daemon selected with WithHost.
+func NetworkListWithContext(ctx context.Context) ([]NetworkSummary, error) {
+	cmd := dockerCommand(ctx, "network", "ls", "--no-trunc", "--format", "{{json .}}")
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("output command: %s", cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not list networks (stderr=%q)", errOutput.String())
+	}
+
+	var networks []NetworkSummary
+	err = unmarshalJSONLines(output, &networks)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't unmarshal networks")
+	}
+	return networks, nil
+}
+
+// NetworkExists function checks if the Docker network with the given name exists.
+func NetworkExists(name string) (bool, error) {
+	return NetworkExistsWithContext(context.Background(), name)
+}
+
+// NetworkExistsWithContext function checks if the Docker network exists like NetworkExists, using the Docker daemon selected with WithHost.
+func NetworkExistsWithContext(ctx context.Context, name string) (bool, error) {
+	cmd := dockerCommand(ctx, "network", "ls", "--filter", "name="+name, "--format", "{{.Name}}")
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("output command: %s", cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return false, errors.Wrapf(err, "could not list networks (stderr=%q)", errOutput.String())
+	}
+
+	// The name filter matches also partial names, so the exact name needs to be checked.
+	for _, networkName := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if networkName == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // ConnectToNetwork function connects the container to the selected Docker network.
 func ConnectToNetwork(containerID, network string) error {
-	cmd := exec.Command("docker", "network", "connect", network, containerID)
+	return ConnectToNetworkWithContext(context.Background(), containerID, network)
+}
+
+// ConnectToNetworkWithContext function connects the container to the network like ConnectToNetwork, using the Docker daemon selected with WithHost.
+func ConnectToNetworkWithContext(ctx context.Context, containerID, network string) error {
+	cmd := dockerCommand(ctx, "network", "connect", network, containerID)
 	errOutput := new(bytes.Buffer)
 	cmd.Stderr = errOutput
 
@@ -119,11 +536,34 @@ func ConnectToNetwork(containerID, network string) error {
 	return nil
 }
 
+// DisconnectFromNetwork function disconnects the container from the selected Docker network.
+func DisconnectFromNetwork(containerID, network string) error {
+	return DisconnectFromNetworkWithContext(context.Background(), containerID, network)
+}
+
+// DisconnectFromNetworkWithContext function disconnects the container from the network like DisconnectFromNetwork, using the Docker daemon selected with WithHost.
+func DisconnectFromNetworkWithContext(ctx context.Context, containerID, network string) error {
+	cmd := dockerCommand(ctx, "network", "disconnect", network, containerID)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not detach container from the network (stderr=%q)", errOutput.String())
+	}
+	return nil
+}
+
 // InspectContainers function inspects selected Docker containers.
 func InspectContainers(containerIDs ...string) ([]ContainerDescription, error) {
+	return InspectContainersWithContext(context.Background(), containerIDs...)
+}
+
+// InspectContainersWithContext function inspects selected Docker containers like InspectContainers, using the Docker daemon selected with WithHost.
+func InspectContainersWithContext(ctx context.Context, containerIDs ...string) ([]ContainerDescription, error) {
 	args := []string{"inspect"}
 	args = append(args, containerIDs...)
-	cmd := exec.Command("docker", args...)
+	cmd := dockerCommand(ctx, args...)
 
 	errOutput := new(bytes.Buffer)
 	cmd.Stderr = errOutput
@@ -142,9 +582,64 @@ func InspectContainers(containerIDs ...string) ([]ContainerDescription, error) {
 	return containerDescriptions, nil
 }
 
+// InspectAllContainers function inspects selected Docker containers, including stopped and exited ones.
+// If no container is selected, all containers listed by "docker ps -a" are inspected.
+func InspectAllContainers(containerIDs ...string) ([]ContainerDescription, error) {
+	return InspectAllContainersWithContext(context.Background(), containerIDs...)
+}
+
+// InspectAllContainersWithContext function inspects Docker containers like InspectAllContainers, using the Docker daemon selected with WithHost.
+func InspectAllContainersWithContext(ctx context.Context, containerIDs ...string) ([]ContainerDescription, error) {
+	if len(containerIDs) > 0 {
+		return InspectContainersWithContext(ctx, containerIDs...)
+	}
+
+	cmd := dockerCommand(ctx, "ps", "--all", "--quiet")
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("output command: %s", cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not list containers (stderr=%q)", errOutput.String())
+	}
+
+	output = bytes.TrimSpace(output)
+	if len(output) == 0 {
+		return nil, nil
+	}
+	for _, id := range bytes.Split(output, []byte{'\n'}) {
+		containerIDs = append(containerIDs, string(id))
+	}
+	return InspectContainersWithContext(ctx, containerIDs...)
+}
+
+// InspectContainersByLabel function inspects all Docker containers, including stopped ones,
+// labeled with the given key and value.
+func InspectContainersByLabel(key, value string) ([]ContainerDescription, error) {
+	return InspectContainersByLabelWithContext(context.Background(), key, value)
+}
+
+// InspectContainersByLabelWithContext function inspects labeled containers like InspectContainersByLabel, using the Docker daemon selected with WithHost.
+func InspectContainersByLabelWithContext(ctx context.Context, key, value string) ([]ContainerDescription, error) {
+	containerIDs, err := containerIDsByLabel(ctx, true, key, value)
+	if err != nil {
+		return nil, err
+	}
+	if len(containerIDs) == 0 {
+		return nil, nil
+	}
+	return InspectContainersWithContext(ctx, containerIDs...)
+}
+
 // Copy function copies resources from the container to the local destination.
 func Copy(containerName, containerPath, localPath string) error {
-	cmd := exec.Command("docker", "cp", containerName+":"+containerPath, localPath)
+	return CopyWithContext(context.Background(), containerName, containerPath, localPath)
+}
+
+// CopyWithContext function copies resources from the container like Copy, using the Docker daemon selected with WithHost.
+func CopyWithContext(ctx context.Context, containerName, containerPath, localPath string) error {
+	cmd := dockerCommand(ctx, "cp", containerName+":"+containerPath, localPath)
 	errOutput := new(bytes.Buffer)
 	cmd.Stderr = errOutput
 
@@ -154,3 +649,129 @@ func Copy(containerName, containerPath, localPath string) error {
 	}
 	return nil
 }
+
+// CopyToContainer function copies resources from the local source to the container.
+func CopyToContainer(containerName, localPath, containerPath string) error {
+	return CopyToContainerWithContext(context.Background(), containerName, localPath, containerPath)
+}
+
+// CopyToContainerWithContext function copies resources to the container like CopyToContainer, using the Docker daemon selected with WithHost.
+func CopyToContainerWithContext(ctx context.Context, containerName, localPath, containerPath string) error {
+	cmd := dockerCommand(ctx, "cp", localPath, containerName+":"+containerPath)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not copy files to the container (stderr=%q)", errOutput.String())
+	}
+	return nil
+}
+
+// ExecInContainer function runs the command inside the running container and returns its exit code.
+// Output of the command is streamed to the given writers. A non-zero exit code is not reported as an error.
+func ExecInContainer(containerID string, command []string, stdout, stderr io.Writer) (int, error) {
+	return ExecInContainerWithContext(context.Background(), containerID, command, stdout, stderr)
+}
+
+// ExecInContainerWithContext function runs the command inside the running container like ExecInContainer,
+// the command is killed when the context is cancelled.
+func ExecInContainerWithContext(ctx context.Context, containerID string, command []string, stdout, stderr io.Writer) (int, error) {
+	args := []string{"exec", containerID}
+	args = append(args, command...)
+	cmd := dockerCommand(ctx, args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	logger.Debugf("run command: %s", cmd)
+	err := cmd.Run()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && ctx.Err() == nil {
+			return exitErr.ExitCode(), nil
+		}
+		return -1, errors.Wrapf(err, "could not execute command in the container (ID: %s)", containerID)
+	}
+	return 0, nil
+}
+
+// WaitForHealthy function polls the container until its health status is "healthy" or the timeout expires.
+func WaitForHealthy(containerID string, timeout time.Duration, pollInterval time.Duration) error {
+	return WaitForHealthyWithContext(context.Background(), containerID, timeout, pollInterval)
+}
+
+// WaitForHealthyWithContext function polls the container like WaitForHealthy, it stops waiting when the context is cancelled.
+func WaitForHealthyWithContext(ctx context.Context, containerID string, timeout time.Duration, pollInterval time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	var lastDescription ContainerDescription
+	for {
+		if signal.SIGINT() {
+			return errors.New("SIGINT: cancel waiting for healthy container")
+		}
+
+		descriptions, err := InspectContainersWithContext(ctx, containerID)
+		if err != nil {
+			return err
+		}
+		if len(descriptions) != 1 {
+			return fmt.Errorf("expected single container description (ID: %s)", containerID)
+		}
+		lastDescription = descriptions[0]
+		logger.Debugf("Container status: %s", lastDescription.String())
+
+		if lastDescription.State.Health == nil {
+			return fmt.Errorf("container (ID: %s) has no healthcheck defined", containerID)
+		}
+		if lastDescription.IsHealthy() {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			break
+		}
+
+		// NOTE: using sleep does not guarantee interval but it's ok for this use case
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "cancel waiting for healthy container (ID: %s)", containerID)
+		case <-time.After(pollInterval):
+		}
+	}
+
+	var lastOutput string
+	if logs := lastDescription.State.Health.Log; len(logs) > 0 {
+		lastOutput = strings.TrimSpace(logs[len(logs)-1].Output)
+	}
+	return fmt.Errorf("timeout waiting for healthy container (ID: %s, status: %s, last health check output: %q)",
+		containerID, lastDescription.State.Health.Status, lastOutput)
+}
+
+// Logs function streams logs of the container to the writer. If follow is set, the function
+// keeps streaming until the container stops. Only entries newer than since are included,
+// unless since is zero.
+func Logs(containerID string, follow bool, since time.Time, writer io.Writer) error {
+	return LogsWithContext(context.Background(), containerID, follow, since, writer)
+}
+
+// LogsWithContext function streams logs of the container like Logs, streaming stops when the context is cancelled.
+func LogsWithContext(ctx context.Context, containerID string, follow bool, since time.Time, writer io.Writer) error {
+	args := []string{"logs", "--timestamps"}
+	if follow {
+		args = append(args, "--follow")
+	}
+	if !since.IsZero() {
+		args = append(args, "--since", since.Format(time.RFC3339Nano))
+	}
+	args = append(args, containerID)
+
+	cmd := dockerCommand(ctx, args...)
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not read logs of the container (ID: %s)", containerID)
+	}
+	return nil
+}
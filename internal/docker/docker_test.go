@@ -0,0 +1,137 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package docker
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalJSONLines(t *testing.T) {
+	output := []byte(`{"ID":"abc","Hostname":"node-1","ManagerStatus":"Leader"}
+{"ID":"def","Hostname":"node-2","ManagerStatus":""}
+`)
+
+	var nodes []SwarmNode
+	err := unmarshalJSONLines(output, &nodes)
+	require.NoError(t, err)
+	require.Len(t, nodes, 2)
+	assert.Equal(t, "abc", nodes[0].ID)
+	assert.Equal(t, "node-2", nodes[1].Hostname)
+
+	nodes = nil
+	err = unmarshalJSONLines([]byte("\n"), &nodes)
+	require.NoError(t, err)
+	assert.Empty(t, nodes)
+
+	err = unmarshalJSONLines([]byte("not json"), &nodes)
+	assert.Error(t, err)
+}
+
+func TestRunArgs(t *testing.T) {
+	args := runArgs(RunOptions{
+		Image:   "alpine:3",
+		Command: []string{"echo", "hello"},
+		Env:     map[string]string{"B": "2", "A": "1"},
+		Volumes: []string{"/tmp:/data:ro"},
+		Network: "elastic-package-stack_default",
+		Remove:  true,
+	})
+
+	assert.Equal(t, []string{
+		"run", "--rm",
+		"--network", "elastic-package-stack_default",
+		"--env", "A=1", "--env", "B=2",
+		"--volume", "/tmp:/data:ro",
+		"alpine:3", "echo", "hello",
+	}, args)
+}
+
+func TestContainerDescriptionState(t *testing.T) {
+	var c ContainerDescription
+	err := json.Unmarshal([]byte(`{"State":{"Status":"running","ExitCode":0,"Health":{"Status":"healthy"}}}`), &c)
+	require.NoError(t, err)
+	assert.True(t, c.IsRunning())
+	assert.True(t, c.IsHealthy())
+	assert.False(t, c.IsFailed())
+
+	c = ContainerDescription{}
+	err = json.Unmarshal([]byte(`{"State":{"Status":"exited","ExitCode":1}}`), &c)
+	require.NoError(t, err)
+	assert.False(t, c.IsRunning())
+	assert.False(t, c.IsHealthy())
+	assert.True(t, c.IsFailed())
+}
+
+func TestNetworkDescriptionContainerNames(t *testing.T) {
+	var n NetworkDescription
+	err := json.Unmarshal([]byte(`{"Containers":{"b1":{"Name":"kibana"},"a2":{"Name":"elasticsearch"},"c3":{"Name":"fleet-server"}}}`), &n)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"elasticsearch", "fleet-server", "kibana"}, n.ContainerNames())
+	assert.True(t, n.HasContainer("kibana"))
+	assert.False(t, n.HasContainer("elastic-agent"))
+}
+
+func TestParseReclaimedSpace(t *testing.T) {
+	space, err := parseReclaimedSpace([]byte("Deleted Containers:\nabc\ndef\n\nTotal reclaimed space: 1.5MB\n"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500000), space)
+
+	space, err = parseReclaimedSpace([]byte("Total reclaimed space: 0B\n"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), space)
+
+	_, err = parseReclaimedSpace([]byte("unexpected output"))
+	assert.Error(t, err)
+}
+
+func TestRegistryHost(t *testing.T) {
+	cases := map[string]string{
+		"alpine":                      "docker.io",
+		"elastic/elastic-agent:8.0.0": "docker.io",
+		"docker.elastic.co/elasticsearch/elasticsearch": "docker.elastic.co",
+		"localhost/image":             "localhost",
+		"localhost:5000/image:latest": "localhost:5000",
+	}
+
+	for image, expected := range cases {
+		assert.Equal(t, expected, registryHost(image), image)
+	}
+}
+
+func TestParseContainerSummaries(t *testing.T) {
+	output := []byte(`{"ID":"1a2b","Image":"docker.elastic.co/kibana/kibana:8.1.0","Labels":"com.docker.compose.project=elastic-package-stack,com.docker.compose.service=kibana","Names":"elastic-package-stack_kibana_1","Status":"Up 2 minutes (healthy)"}
+{"ID":"3c4d","Image":"busybox","Labels":"","Names":"first,second","Status":"Exited (0) 1 hour ago"}
+`)
+
+	containers, err := parseContainerSummaries(output)
+	require.NoError(t, err)
+	require.Len(t, containers, 2)
+
+	var descriptions []ContainerDescription
+	err = json.Unmarshal([]byte(`[
+		{"ID":"1a2b","Config":{"Labels":{"com.docker.compose.project":"elastic-package-stack","com.docker.compose.service":"kibana","com.docker.compose.config-hash":"a=1,b=2"}}},
+		{"ID":"3c4d","Config":{"Labels":null}}
+	]`), &descriptions)
+	require.NoError(t, err)
+	setContainerLabels(containers, descriptions)
+
+	assert.Equal(t, ContainerSummary{
+		ID:     "1a2b",
+		Names:  []string{"elastic-package-stack_kibana_1"},
+		Image:  "docker.elastic.co/kibana/kibana:8.1.0",
+		Status: "Up 2 minutes (healthy)",
+		Labels: map[string]string{
+			"com.docker.compose.project":     "elastic-package-stack",
+			"com.docker.compose.service":     "kibana",
+			"com.docker.compose.config-hash": "a=1,b=2",
+		},
+	}, containers[0])
+	assert.Equal(t, []string{"first", "second"}, containers[1].Names)
+	assert.Empty(t, containers[1].Labels)
+}
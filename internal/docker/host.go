@@ -0,0 +1,40 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package docker
+
+import (
+	"context"
+	"os"
+	"os/exec"
+)
+
+// HostEnv is the environment variable selecting the Docker daemon used by docker and docker-compose commands.
+const HostEnv = "DOCKER_HOST"
+
+type hostKey struct{}
+
+// WithHost function returns a copy of the context selecting the Docker daemon (e.g. tcp://10.0.0.5:2376)
+// for commands run with the context. The default Docker daemon is used if the host is empty.
+func WithHost(ctx context.Context, host string) context.Context {
+	return context.WithValue(ctx, hostKey{}, host)
+}
+
+// dockerCommand function creates the docker command aborted when the context is cancelled.
+// The Docker daemon selected with WithHost is passed in the environment of the command.
+func dockerCommand(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	if host, _ := ctx.Value(hostKey{}).(string); host != "" {
+		cmd.Env = append(os.Environ(), HostEnv+"="+host)
+	}
+	return cmd
+}
+
+// appendEnv function adds environment variables to the command, keeping the Docker daemon selected with WithHost.
+func appendEnv(cmd *exec.Cmd, env ...string) {
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, env...)
+}
@@ -0,0 +1,47 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDockerCommandHost(t *testing.T) {
+	t.Setenv(HostEnv, "unix:///var/run/other.sock")
+
+	cmd := dockerCommand(context.Background(), "ps")
+	assert.Nil(t, cmd.Env)
+
+	cmd = dockerCommand(WithHost(context.Background(), ""), "ps")
+	assert.Nil(t, cmd.Env)
+
+	cmd = dockerCommand(WithHost(context.Background(), "tcp://10.0.0.5:2376"), "ps")
+	assert.Equal(t, "tcp://10.0.0.5:2376", lastEnv(cmd.Env, HostEnv))
+}
+
+func TestAppendEnv(t *testing.T) {
+	cmd := dockerCommand(WithHost(context.Background(), "tcp://10.0.0.5:2376"), "compose", "up")
+	appendEnv(cmd, "STACK_VERSION=8.1.0")
+	assert.Equal(t, "tcp://10.0.0.5:2376", lastEnv(cmd.Env, HostEnv))
+	assert.Equal(t, "8.1.0", lastEnv(cmd.Env, "STACK_VERSION"))
+
+	cmd = dockerCommand(context.Background(), "compose", "up")
+	appendEnv(cmd, "STACK_VERSION=8.1.0")
+	assert.Equal(t, "8.1.0", lastEnv(cmd.Env, "STACK_VERSION"))
+	assert.Greater(t, len(cmd.Env), 1)
+}
+
+func lastEnv(env []string, name string) string {
+	var value string
+	for _, kv := range env {
+		if len(kv) > len(name) && kv[:len(name)+1] == name+"=" {
+			value = kv[len(name)+1:]
+		}
+	}
+	return value
+}
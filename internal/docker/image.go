@@ -0,0 +1,142 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/elastic-package/internal/logger"
+)
+
+// ImageDescription describes the Docker image.
+type ImageDescription struct {
+	ID          string
+	RepoTags    []string
+	RepoDigests []string
+	Created     time.Time
+	Size        int64
+	Config      struct {
+		Labels map[string]string
+	}
+}
+
+// InspectImage function returns the image description for the selected image.
+func InspectImage(imageRef string) (ImageDescription, error) {
+	return InspectImageWithContext(context.Background(), imageRef)
+}
+
+// InspectImageWithContext function returns the image description like InspectImage, using the Docker daemon selected with WithHost.
+func InspectImageWithContext(ctx context.Context, imageRef string) (ImageDescription, error) {
+	cmd := dockerCommand(ctx, "image", "inspect", imageRef)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("output command: %s", cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return ImageDescription{}, errors.Wrapf(err, "could not inspect the image (stderr=%q)", errOutput.String())
+	}
+
+	var imageDescriptions []ImageDescription
+	err = json.Unmarshal(output, &imageDescriptions)
+	if err != nil {
+		return ImageDescription{}, errors.Wrapf(err, "can't unmarshal image inspect for %s (stderr=%q)", imageRef, errOutput.String())
+	}
+	if len(imageDescriptions) != 1 {
+		return ImageDescription{}, fmt.Errorf("expected single image description for %s", imageRef)
+	}
+	return imageDescriptions[0], nil
+}
+
+// ImageExists function checks if the image is present locally.
+func ImageExists(imageRef string) (bool, error) {
+	return ImageExistsWithContext(context.Background(), imageRef)
+}
+
+// ImageExistsWithContext function checks if the image is present like ImageExists, using the Docker daemon selected with WithHost.
+func ImageExistsWithContext(ctx context.Context, imageRef string) (bool, error) {
+	cmd := dockerCommand(ctx, "image", "inspect", "--format", "{{.ID}}", imageRef)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("run command: %s", cmd)
+	err := cmd.Run()
+	if err != nil {
+		if strings.Contains(strings.ToLower(errOutput.String()), "no such image") {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "could not inspect the image (stderr=%q)", errOutput.String())
+	}
+	return true, nil
+}
+
+// BuildImage function builds the image from the Dockerfile and tags it. Paths in the Dockerfile are
+// resolved relative to the context directory. Build output is printed in debug mode.
+func BuildImage(dockerfile, contextDir, tag string) error {
+	return BuildImageWithContext(context.Background(), dockerfile, contextDir, tag)
+}
+
+// BuildImageWithContext function builds the image like BuildImage, using the Docker daemon selected with WithHost.
+func BuildImageWithContext(ctx context.Context, dockerfile, contextDir, tag string) error {
+	cmd := dockerCommand(ctx, "build", "-f", dockerfile, "-t", tag, contextDir)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	if logger.IsDebugMode() {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = io.MultiWriter(errOutput, os.Stderr)
+	}
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not build image %s (stderr=%q)", tag, errOutput.String())
+	}
+	return nil
+}
+
+// SaveImage function writes the image to the tar archive, so it can be loaded on another host with LoadImage.
+func SaveImage(image, destPath string) error {
+	return SaveImageWithContext(context.Background(), image, destPath)
+}
+
+// SaveImageWithContext function writes the image to the tar archive like SaveImage, using the Docker daemon selected with WithHost.
+func SaveImageWithContext(ctx context.Context, image, destPath string) error {
+	cmd := dockerCommand(ctx, "save", "-o", destPath, image)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not save image %s to %s (stderr=%q)", image, destPath, errOutput.String())
+	}
+	return nil
+}
+
+// LoadImage function loads images from the tar archive created with SaveImage.
+func LoadImage(srcPath string) error {
+	return LoadImageWithContext(context.Background(), srcPath)
+}
+
+// LoadImageWithContext function loads images from the tar archive like LoadImage, using the Docker daemon selected with WithHost.
+func LoadImageWithContext(ctx context.Context, srcPath string) error {
+	cmd := dockerCommand(ctx, "load", "-i", srcPath)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not load images from %s (stderr=%q)", srcPath, errOutput.String())
+	}
+	return nil
+}
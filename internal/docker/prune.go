@@ -0,0 +1,97 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package docker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/elastic-package/internal/logger"
+)
+
+const reclaimedSpacePrefix = "Total reclaimed space:"
+
+// Prune function removes stopped containers, dangling images and unused volumes, depending on selected options.
+// It returns the total disk space reclaimed, in bytes.
+func Prune(pruneContainers, pruneImages, pruneVolumes bool) (int64, error) {
+	return PruneWithContext(context.Background(), pruneContainers, pruneImages, pruneVolumes)
+}
+
+// PruneWithContext function removes unused resources like Prune, using the Docker daemon selected with WithHost.
+func PruneWithContext(ctx context.Context, pruneContainers, pruneImages, pruneVolumes bool) (int64, error) {
+	var reclaimed int64
+	for _, resource := range []struct {
+		name     string
+		selected bool
+	}{
+		{"container", pruneContainers},
+		{"image", pruneImages},
+		{"volume", pruneVolumes},
+	} {
+		if !resource.selected {
+			continue
+		}
+
+		space, err := prune(ctx, resource.name)
+		if err != nil {
+			return reclaimed, err
+		}
+		reclaimed += space
+	}
+	return reclaimed, nil
+}
+
+// ImagePrune function removes dangling images, or all images not used by any container if all is set.
+// It returns the disk space reclaimed, in bytes.
+func ImagePrune(all bool) (int64, error) {
+	return ImagePruneWithContext(context.Background(), all)
+}
+
+// ImagePruneWithContext function removes unused images like ImagePrune, using the Docker daemon selected with WithHost.
+func ImagePruneWithContext(ctx context.Context, all bool) (int64, error) {
+	if all {
+		return prune(ctx, "image", "--all")
+	}
+	return prune(ctx, "image")
+}
+
+func prune(ctx context.Context, resource string, extraArgs ...string) (int64, error) {
+	args := append([]string{resource, "prune", "--force"}, extraArgs...)
+	cmd := dockerCommand(ctx, args...)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("output command: %s", cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not prune %s resources (stderr=%q)", resource, errOutput.String())
+	}
+
+	space, err := parseReclaimedSpace(output)
+	if err != nil {
+		return 0, errors.Wrapf(err, "can't read space reclaimed by %s prune", resource)
+	}
+	return space, nil
+}
+
+// parseReclaimedSpace parses the summary line printed by "docker prune" commands, e.g. "Total reclaimed space: 1.5GB".
+func parseReclaimedSpace(output []byte) (int64, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, reclaimedSpacePrefix) {
+			continue
+		}
+		return parseSize(strings.TrimPrefix(line, reclaimedSpacePrefix))
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, errors.New("reclaimed space not found in the command output")
+}
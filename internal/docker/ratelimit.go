@@ -0,0 +1,71 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package docker
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/elastic/elastic-package/internal/logger"
+)
+
+const defaultRegistry = "docker.io"
+
+var (
+	pullLimiters      = map[string]*rate.Limiter{}
+	pullLimitersMutex sync.Mutex
+)
+
+// PullRateLimited function pulls the image, limiting the number of pulls from the same registry
+// to the given number of requests per minute. If the limit is reached, it waits before pulling.
+func PullRateLimited(image string, requestsPerMinute int) error {
+	if requestsPerMinute <= 0 {
+		return fmt.Errorf("requests per minute must be positive (value: %d)", requestsPerMinute)
+	}
+
+	registry := registryHost(image)
+	reservation := pullLimiter(registry, requestsPerMinute).Reserve()
+	if delay := reservation.Delay(); delay > 0 {
+		logger.Warnf("Pull rate limit for registry %s reached (%d per minute), waiting %s before pulling %s",
+			registry, requestsPerMinute, delay.Round(time.Millisecond), image)
+		time.Sleep(delay)
+	}
+	return Pull(image)
+}
+
+// pullLimiter returns the limiter shared by all pulls from the registry.
+func pullLimiter(registry string, requestsPerMinute int) *rate.Limiter {
+	pullLimitersMutex.Lock()
+	defer pullLimitersMutex.Unlock()
+
+	limit := rate.Every(time.Minute / time.Duration(requestsPerMinute))
+	limiter, found := pullLimiters[registry]
+	if !found {
+		limiter = rate.NewLimiter(limit, 1)
+		pullLimiters[registry] = limiter
+	} else if limiter.Limit() != limit {
+		limiter.SetLimit(limit)
+	}
+	return limiter
+}
+
+// registryHost returns the hostname of the registry the image reference points to.
+// References without a registry hostname, like "elastic/elastic-agent:8.0.0", point to Docker Hub.
+func registryHost(image string) string {
+	i := strings.Index(image, "/")
+	if i < 0 {
+		return defaultRegistry
+	}
+
+	host := image[:i]
+	if host == "localhost" || strings.ContainsAny(host, ".:") {
+		return host
+	}
+	return defaultRegistry
+}
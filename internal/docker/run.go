@@ -0,0 +1,87 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/elastic-package/internal/logger"
+)
+
+// RunOptions defines options of a one-off container run.
+type RunOptions struct {
+	Image   string
+	Command []string
+
+	Env     map[string]string
+	Volumes []string // in form of "source:target[:options]"
+	Network string
+
+	// Remove removes the container when it exits.
+	Remove bool
+
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// RunContainer function runs the command in a new container and waits until it exits.
+func RunContainer(opts RunOptions) error {
+	return RunContainerWithContext(context.Background(), opts)
+}
+
+// RunContainerWithContext function runs the command in a new container like RunContainer,
+// the docker client is killed when the context is cancelled.
+func RunContainerWithContext(ctx context.Context, opts RunOptions) error {
+	if opts.Image == "" {
+		return errors.New("image is required to run a container")
+	}
+
+	cmd := dockerCommand(ctx, runArgs(opts)...)
+	errOutput := new(bytes.Buffer)
+	cmd.Stdout = opts.Stdout
+	cmd.Stderr = errOutput
+	if opts.Stderr != nil {
+		cmd.Stderr = io.MultiWriter(errOutput, opts.Stderr)
+	}
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not run container (image: %s, stderr=%q)", opts.Image, errOutput.String())
+	}
+	return nil
+}
+
+func runArgs(opts RunOptions) []string {
+	args := []string{"run"}
+	if opts.Remove {
+		args = append(args, "--rm")
+	}
+	if opts.Network != "" {
+		args = append(args, "--network", opts.Network)
+	}
+
+	envNames := make([]string, 0, len(opts.Env))
+	for name := range opts.Env {
+		envNames = append(envNames, name)
+	}
+	sort.Strings(envNames)
+	for _, name := range envNames {
+		args = append(args, "--env", fmt.Sprintf("%s=%s", name, opts.Env[name]))
+	}
+
+	for _, volume := range opts.Volumes {
+		args = append(args, "--volume", volume)
+	}
+
+	args = append(args, opts.Image)
+	args = append(args, opts.Command...)
+	return args
+}
@@ -0,0 +1,137 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/elastic-package/internal/logger"
+)
+
+// StatsSnapshot describes resource usage of the Docker container at a point in time.
+type StatsSnapshot struct {
+	CPUPercent    float64
+	MemoryUsage   int64
+	MemoryLimit   int64
+	NetworkInput  int64
+	NetworkOutput int64
+}
+
+// rawStats is the JSON document printed by "docker stats --format {{json .}}".
+type rawStats struct {
+	CPUPerc  string
+	MemUsage string
+	NetIO    string
+}
+
+// ContainerStats function returns the current resource usage of the container.
+func ContainerStats(containerID string) (StatsSnapshot, error) {
+	return ContainerStatsWithContext(context.Background(), containerID)
+}
+
+// ContainerStatsWithContext function returns the resource usage of the container like ContainerStats, using the Docker daemon selected with WithHost.
+func ContainerStatsWithContext(ctx context.Context, containerID string) (StatsSnapshot, error) {
+	cmd := dockerCommand(ctx, "stats", "--no-stream", "--format", "{{json .}}", containerID)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("output command: %s", cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return StatsSnapshot{}, errors.Wrapf(err, "could not read container stats (stderr=%q)", errOutput.String())
+	}
+
+	var raw rawStats
+	err = json.Unmarshal(output, &raw)
+	if err != nil {
+		return StatsSnapshot{}, errors.Wrapf(err, "can't unmarshal container stats for %s", containerID)
+	}
+
+	snapshot, err := parseStats(raw)
+	if err != nil {
+		return StatsSnapshot{}, errors.Wrapf(err, "can't parse container stats for %s", containerID)
+	}
+	return snapshot, nil
+}
+
+func parseStats(raw rawStats) (StatsSnapshot, error) {
+	var snapshot StatsSnapshot
+	var err error
+
+	snapshot.CPUPercent, err = strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(raw.CPUPerc), "%"), 64)
+	if err != nil {
+		return StatsSnapshot{}, errors.Wrapf(err, "invalid CPU percentage (value: %s)", raw.CPUPerc)
+	}
+
+	snapshot.MemoryUsage, snapshot.MemoryLimit, err = parseSizePair(raw.MemUsage)
+	if err != nil {
+		return StatsSnapshot{}, errors.Wrap(err, "invalid memory usage")
+	}
+
+	snapshot.NetworkInput, snapshot.NetworkOutput, err = parseSizePair(raw.NetIO)
+	if err != nil {
+		return StatsSnapshot{}, errors.Wrap(err, "invalid network I/O")
+	}
+	return snapshot, nil
+}
+
+// parseSizePair parses values in form of "1.5MiB / 2GiB".
+func parseSizePair(value string) (int64, int64, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("expected two values separated with \"/\" (value: %s)", value)
+	}
+
+	first, err := parseSize(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	second, err := parseSize(parts[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return first, second, nil
+}
+
+var sizeUnits = []struct {
+	suffix     string
+	multiplier float64
+}{
+	// Longer suffixes must go first, so "MiB" is not matched as "B".
+	{"KiB", 1 << 10},
+	{"MiB", 1 << 20},
+	{"GiB", 1 << 30},
+	{"TiB", 1 << 40},
+	{"kB", 1e3},
+	{"KB", 1e3},
+	{"MB", 1e6},
+	{"GB", 1e9},
+	{"TB", 1e12},
+	{"B", 1},
+}
+
+// parseSize parses human-readable sizes printed by Docker, both decimal (kB, MB) and binary (KiB, MiB).
+func parseSize(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	for _, unit := range sizeUnits {
+		if !strings.HasSuffix(value, unit.suffix) {
+			continue
+		}
+
+		number, err := strconv.ParseFloat(strings.TrimSuffix(value, unit.suffix), 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "invalid size (value: %s)", value)
+		}
+		return int64(number * unit.multiplier), nil
+	}
+	return 0, fmt.Errorf("unknown size unit (value: %s)", value)
+}
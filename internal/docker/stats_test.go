@@ -0,0 +1,42 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStats(t *testing.T) {
+	snapshot, err := parseStats(rawStats{
+		CPUPerc:  "12.50%",
+		MemUsage: "512MiB / 2GiB",
+		NetIO:    "1.5kB / 0B",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, StatsSnapshot{
+		CPUPercent:    12.5,
+		MemoryUsage:   512 * 1024 * 1024,
+		MemoryLimit:   2 * 1024 * 1024 * 1024,
+		NetworkInput:  1500,
+		NetworkOutput: 0,
+	}, snapshot)
+}
+
+func TestParseStatsInvalid(t *testing.T) {
+	cases := []rawStats{
+		{CPUPerc: "n/a", MemUsage: "1MiB / 2MiB", NetIO: "0B / 0B"},
+		{CPUPerc: "1%", MemUsage: "1MiB", NetIO: "0B / 0B"},
+		{CPUPerc: "1%", MemUsage: "1MiB / 2MiB", NetIO: "0XB / 0B"},
+	}
+
+	for _, c := range cases {
+		_, err := parseStats(c)
+		assert.Error(t, err, "stats: %+v", c)
+	}
+}
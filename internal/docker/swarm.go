@@ -0,0 +1,387 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/elastic-package/internal/logger"
+)
+
+// SwarmNode describes the Docker Swarm node.
+type SwarmNode struct {
+	ID            string
+	Hostname      string
+	Role          string
+	Status        string
+	Availability  string
+	ManagerStatus string
+}
+
+// SwarmNodeList function returns nodes of the swarm the Docker daemon is part of.
+func SwarmNodeList() ([]SwarmNode, error) {
+	return SwarmNodeListWithContext(context.Background())
+}
+
+// SwarmNodeListWithContext function returns nodes of the swarm like SwarmNodeList, using the Docker daemon selected with WithHost.
+func SwarmNodeListWithContext(ctx context.Context) ([]SwarmNode, error) {
+	cmd := dockerCommand(ctx, "node", "ls", "--format", "{{json .}}")
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("output command: %s", cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not list swarm nodes (stderr=%q)", errOutput.String())
+	}
+
+	var nodes []SwarmNode
+	err = unmarshalJSONLines(output, &nodes)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't unmarshal swarm nodes")
+	}
+
+	for i, node := range nodes {
+		// "docker node ls" doesn't report the role, only the manager status, which is empty for workers.
+		if node.ManagerStatus != "" {
+			nodes[i].Role = "manager"
+		} else {
+			nodes[i].Role = "worker"
+		}
+	}
+	return nodes, nil
+}
+
+// SwarmNodeDrain function stops scheduling tasks on the swarm node and moves its running tasks to other nodes.
+func SwarmNodeDrain(nodeID string) error {
+	return SwarmNodeDrainWithContext(context.Background(), nodeID)
+}
+
+// SwarmNodeDrainWithContext function drains the swarm node like SwarmNodeDrain, using the Docker daemon selected with WithHost.
+func SwarmNodeDrainWithContext(ctx context.Context, nodeID string) error {
+	return swarmNodeSetAvailability(ctx, nodeID, "drain")
+}
+
+// SwarmNodeActivate function makes the swarm node available for scheduling tasks.
+func SwarmNodeActivate(nodeID string) error {
+	return SwarmNodeActivateWithContext(context.Background(), nodeID)
+}
+
+// SwarmNodeActivateWithContext function makes the swarm node available like SwarmNodeActivate, using the Docker daemon selected with WithHost.
+func SwarmNodeActivateWithContext(ctx context.Context, nodeID string) error {
+	return swarmNodeSetAvailability(ctx, nodeID, "active")
+}
+
+func swarmNodeSetAvailability(ctx context.Context, nodeID, availability string) error {
+	cmd := dockerCommand(ctx, "node", "update", "--availability", availability, nodeID)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not set availability of node %s to %s (stderr=%q)", nodeID, availability, errOutput.String())
+	}
+	return nil
+}
+
+// SwarmNodePromote function promotes the swarm node to a manager.
+func SwarmNodePromote(nodeID string) error {
+	return SwarmNodePromoteWithContext(context.Background(), nodeID)
+}
+
+// SwarmNodePromoteWithContext function promotes the swarm node like SwarmNodePromote, using the Docker daemon selected with WithHost.
+func SwarmNodePromoteWithContext(ctx context.Context, nodeID string) error {
+	return swarmNodeSetRole(ctx, nodeID, "promote")
+}
+
+// SwarmNodeDemote function demotes the swarm manager node to a worker.
+func SwarmNodeDemote(nodeID string) error {
+	return SwarmNodeDemoteWithContext(context.Background(), nodeID)
+}
+
+// SwarmNodeDemoteWithContext function demotes the swarm node like SwarmNodeDemote, using the Docker daemon selected with WithHost.
+func SwarmNodeDemoteWithContext(ctx context.Context, nodeID string) error {
+	return swarmNodeSetRole(ctx, nodeID, "demote")
+}
+
+func swarmNodeSetRole(ctx context.Context, nodeID, action string) error {
+	cmd := dockerCommand(ctx, "node", action, nodeID)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not %s node %s (stderr=%q)", action, nodeID, errOutput.String())
+	}
+	return nil
+}
+
+// SwarmJoinWorker function joins the Docker daemon to the swarm as a worker node.
+func SwarmJoinWorker(managerAddr, token string) error {
+	return SwarmJoinWorkerWithContext(context.Background(), managerAddr, token)
+}
+
+// SwarmJoinWorkerWithContext function joins the swarm as a worker node like SwarmJoinWorker, using the Docker daemon selected with WithHost.
+func SwarmJoinWorkerWithContext(ctx context.Context, managerAddr, token string) error {
+	cmd := dockerCommand(ctx, "swarm", "join", "--token", token, managerAddr)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	// Join token is a secret, so it's not logged.
+	logger.Debugf("run command: docker swarm join %s", managerAddr)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not join swarm managed by %s (stderr=%q)", managerAddr, errOutput.String())
+	}
+	return nil
+}
+
+// SwarmServiceScale function adjusts the number of replicas of the swarm service.
+func SwarmServiceScale(serviceName string, replicas int) error {
+	return SwarmServiceScaleWithContext(context.Background(), serviceName, replicas)
+}
+
+// SwarmServiceScaleWithContext function scales the swarm service like SwarmServiceScale, using the Docker daemon selected with WithHost.
+func SwarmServiceScaleWithContext(ctx context.Context, serviceName string, replicas int) error {
+	cmd := dockerCommand(ctx, "service", "scale", fmt.Sprintf("%s=%d", serviceName, replicas))
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not scale service %s (stderr=%q)", serviceName, errOutput.String())
+	}
+	return nil
+}
+
+// SwarmUpdateService function sets environment variables of the running swarm service. All variables
+// are updated in a single "docker service update", so tasks of the service are restarted only once.
+func SwarmUpdateService(serviceName string, envVars map[string]string) error {
+	return SwarmUpdateServiceWithContext(context.Background(), serviceName, envVars)
+}
+
+// SwarmUpdateServiceWithContext function sets environment variables of the swarm service like SwarmUpdateService, using the Docker daemon selected with WithHost.
+func SwarmUpdateServiceWithContext(ctx context.Context, serviceName string, envVars map[string]string) error {
+	if len(envVars) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(envVars))
+	for key := range envVars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	args := []string{"service", "update"}
+	for _, key := range keys {
+		args = append(args, "--env-add", fmt.Sprintf("%s=%s", key, envVars[key]))
+	}
+	args = append(args, serviceName)
+
+	cmd := dockerCommand(ctx, args...)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not update service %s (stderr=%q)", serviceName, errOutput.String())
+	}
+	return nil
+}
+
+// SwarmService describes the Docker Swarm service.
+type SwarmService struct {
+	ID       string
+	Name     string
+	Mode     string
+	Replicas string
+	Image    string
+}
+
+// SwarmServiceList function returns services deployed as part of the swarm stack.
+func SwarmServiceList(stackName string) ([]SwarmService, error) {
+	return SwarmServiceListWithContext(context.Background(), stackName)
+}
+
+// SwarmServiceListWithContext function returns services of the swarm stack like SwarmServiceList, using the Docker daemon selected with WithHost.
+func SwarmServiceListWithContext(ctx context.Context, stackName string) ([]SwarmService, error) {
+	cmd := dockerCommand(ctx, "service", "ls",
+		"--filter", "label=com.docker.stack.namespace="+stackName,
+		"--format", "{{json .}}")
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("output command: %s", cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not list swarm services (stderr=%q)", errOutput.String())
+	}
+
+	var services []SwarmService
+	err = unmarshalJSONLines(output, &services)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't unmarshal swarm services of stack %s", stackName)
+	}
+	return services, nil
+}
+
+// SwarmSecretCreate function creates the swarm secret. The value is passed on stdin, so it's not exposed
+// in the process list.
+func SwarmSecretCreate(name, value string) error {
+	return SwarmSecretCreateWithContext(context.Background(), name, value)
+}
+
+// SwarmSecretCreateWithContext function creates the swarm secret like SwarmSecretCreate, using the Docker daemon selected with WithHost.
+func SwarmSecretCreateWithContext(ctx context.Context, name, value string) error {
+	cmd := dockerCommand(ctx, "secret", "create", name, "-")
+	cmd.Stdin = strings.NewReader(value)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not create secret %s (stderr=%q)", name, errOutput.String())
+	}
+	return nil
+}
+
+// SwarmSecretRemove function removes the swarm secret.
+func SwarmSecretRemove(name string) error {
+	return SwarmSecretRemoveWithContext(context.Background(), name)
+}
+
+// SwarmSecretRemoveWithContext function removes the swarm secret like SwarmSecretRemove, using the Docker daemon selected with WithHost.
+func SwarmSecretRemoveWithContext(ctx context.Context, name string) error {
+	cmd := dockerCommand(ctx, "secret", "rm", name)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not remove secret %s (stderr=%q)", name, errOutput.String())
+	}
+	return nil
+}
+
+// SwarmConfigCreate function creates the swarm config with the given content. Configs are meant
+// for non-sensitive data, secrets should be created with SwarmSecretCreate.
+func SwarmConfigCreate(name string, data []byte) error {
+	return SwarmConfigCreateWithContext(context.Background(), name, data)
+}
+
+// SwarmConfigCreateWithContext function creates the swarm config like SwarmConfigCreate, using the Docker daemon selected with WithHost.
+func SwarmConfigCreateWithContext(ctx context.Context, name string, data []byte) error {
+	cmd := dockerCommand(ctx, "config", "create", name, "-")
+	cmd.Stdin = bytes.NewReader(data)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not create config %s (stderr=%q)", name, errOutput.String())
+	}
+	return nil
+}
+
+// SwarmConfigRemove function removes the swarm config.
+func SwarmConfigRemove(name string) error {
+	return SwarmConfigRemoveWithContext(context.Background(), name)
+}
+
+// SwarmConfigRemoveWithContext function removes the swarm config like SwarmConfigRemove, using the Docker daemon selected with WithHost.
+func SwarmConfigRemoveWithContext(ctx context.Context, name string) error {
+	cmd := dockerCommand(ctx, "config", "rm", name)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not remove config %s (stderr=%q)", name, errOutput.String())
+	}
+	return nil
+}
+
+// StackInfo describes the stack deployed to the swarm.
+type StackInfo struct {
+	Name         string
+	ServiceCount int
+}
+
+// SwarmStackList function returns stacks deployed to the swarm.
+func SwarmStackList() ([]StackInfo, error) {
+	return SwarmStackListWithContext(context.Background())
+}
+
+// SwarmStackListWithContext function returns stacks deployed to the swarm like SwarmStackList, using the Docker daemon selected with WithHost.
+func SwarmStackListWithContext(ctx context.Context) ([]StackInfo, error) {
+	cmd := dockerCommand(ctx, "stack", "ls", "--format", "{{json .}}")
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("output command: %s", cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not list swarm stacks (stderr=%q)", errOutput.String())
+	}
+
+	// "docker stack ls" reports the number of services as a string.
+	var rawStacks []struct {
+		Name     string
+		Services string
+	}
+	err = unmarshalJSONLines(output, &rawStacks)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't unmarshal swarm stacks")
+	}
+
+	var stacks []StackInfo
+	for _, raw := range rawStacks {
+		serviceCount, err := strconv.Atoi(raw.Services)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid number of services of stack %s (value: %s)", raw.Name, raw.Services)
+		}
+		stacks = append(stacks, StackInfo{
+			Name:         raw.Name,
+			ServiceCount: serviceCount,
+		})
+	}
+	return stacks, nil
+}
+
+// SwarmServiceLogs function streams logs of all replicas of the swarm service to the writer.
+// If tail is positive, only the given number of most recent lines is included.
+func SwarmServiceLogs(serviceName string, follow bool, tail int, writer io.Writer) error {
+	return SwarmServiceLogsWithContext(context.Background(), serviceName, follow, tail, writer)
+}
+
+// SwarmServiceLogsWithContext function streams logs of the swarm service like SwarmServiceLogs,
+// streaming stops when the context is cancelled.
+func SwarmServiceLogsWithContext(ctx context.Context, serviceName string, follow bool, tail int, writer io.Writer) error {
+	args := []string{"service", "logs", "--timestamps"}
+	if follow {
+		args = append(args, "--follow")
+	}
+	if tail > 0 {
+		args = append(args, "--tail", strconv.Itoa(tail))
+	}
+	args = append(args, serviceName)
+
+	cmd := dockerCommand(ctx, args...)
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not read logs of the service %s", serviceName)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/elastic-package/internal/logger"
+)
+
+// VolumeCreate function creates a named Docker volume with the given labels.
+func VolumeCreate(name string, labels map[string]string) error {
+	return VolumeCreateWithContext(context.Background(), name, labels)
+}
+
+// VolumeCreateWithContext function creates the Docker volume like VolumeCreate, using the Docker daemon selected with WithHost.
+func VolumeCreateWithContext(ctx context.Context, name string, labels map[string]string) error {
+	args := []string{"volume", "create"}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		args = append(args, "--label", fmt.Sprintf("%s=%s", key, labels[key]))
+	}
+	args = append(args, name)
+
+	cmd := dockerCommand(ctx, args...)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not create volume %s (stderr=%q)", name, errOutput.String())
+	}
+	return nil
+}
+
+// VolumeRemove function removes the named Docker volume. If force is set, the volume is removed
+// even if it's in use.
+func VolumeRemove(name string, force bool) error {
+	return VolumeRemoveWithContext(context.Background(), name, force)
+}
+
+// VolumeRemoveWithContext function removes the Docker volume like VolumeRemove, using the Docker daemon selected with WithHost.
+func VolumeRemoveWithContext(ctx context.Context, name string, force bool) error {
+	args := []string{"volume", "rm"}
+	if force {
+		args = append(args, "--force")
+	}
+	args = append(args, name)
+
+	cmd := dockerCommand(ctx, args...)
+	errOutput := new(bytes.Buffer)
+	cmd.Stderr = errOutput
+
+	logger.Debugf("run command: %s", cmd)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "could not remove volume %s (stderr=%q)", name, errOutput.String())
+	}
+	return nil
+}
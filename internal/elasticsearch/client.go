@@ -34,6 +34,9 @@ type clientOptions struct {
 
 	// skipTLSVerify disables TLS validation.
 	skipTLSVerify bool
+
+	// disableRetry disables retries done by the transport, when requests are retried by the caller.
+	disableRetry bool
 }
 
 // defaultOptionsFromEnv returns clientOptions initialized with values from environmet variables.
@@ -61,6 +64,13 @@ func OptionWithSkipTLSVerify() ClientOption {
 	}
 }
 
+// optionWithDisableRetry disables retries of the transport.
+func optionWithDisableRetry() ClientOption {
+	return func(opts *clientOptions) {
+		opts.disableRetry = true
+	}
+}
+
 // Client method creates new instance of the Elasticsearch client.
 func Client(customOptions ...ClientOption) (*elasticsearch.Client, error) {
 	options := defaultOptionsFromEnv()
@@ -76,6 +86,8 @@ func Client(customOptions ...ClientOption) (*elasticsearch.Client, error) {
 		Addresses: []string{options.address},
 		Username:  options.username,
 		Password:  options.password,
+
+		DisableRetry: options.disableRetry,
 	}
 	if options.skipTLSVerify {
 		config.Transport = &http.Transport{
@@ -8,69 +8,298 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"strings"
 )
 
-// NewError returns a new error constructed from the given response body.
-// This assumes the body contains a JSON encoded error. If the body cannot
-// be parsed then an error is returned that contains the raw body.
+// RootCause describes a single root cause reported in the Elasticsearch error response.
+type RootCause struct {
+	Type          string   `json:"type"`
+	Reason        string   `json:"reason"`
+	ProcessorType string   `json:"processor_type,omitempty"`
+	ScriptStack   []string `json:"script_stack,omitempty"`
+	Script        string   `json:"script,omitempty"`
+	Lang          string   `json:"lang,omitempty"`
+	Position      struct {
+		Offset int `json:"offset"`
+		Start  int `json:"start"`
+		End    int `json:"end"`
+	} `json:"position,omitempty"`
+	Suppressed []struct {
+		Type          string `json:"type"`
+		Reason        string `json:"reason"`
+		ProcessorType string `json:"processor_type"`
+	} `json:"suppressed,omitempty"`
+}
+
+// ShardFailure describes a failure of the operation on a single shard.
+type ShardFailure struct {
+	Shard  int    `json:"shard"`
+	Index  string `json:"index"`
+	Node   string `json:"node"`
+	Reason struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"reason"`
+}
+
+// ErrorCause describes the underlying cause of the Elasticsearch error, causes can be nested.
+type ErrorCause struct {
+	Type     string      `json:"type"`
+	Reason   string      `json:"reason"`
+	CausedBy *ErrorCause `json:"caused_by,omitempty"`
+}
+
+// ErrorBody represents the JSON encoded error returned by Elasticsearch.
 type ErrorBody struct {
 	Error struct {
-		RootCause []struct {
-			Type          string   `json:"type"`
-			Reason        string   `json:"reason"`
-			ProcessorType string   `json:"processor_type,omitempty"`
-			ScriptStack   []string `json:"script_stack,omitempty"`
-			Script        string   `json:"script,omitempty"`
-			Lang          string   `json:"lang,omitempty"`
-			Position      struct {
-				Offset int `json:"offset"`
-				Start  int `json:"start"`
-				End    int `json:"end"`
-			} `json:"position,omitempty"`
-			Suppressed []struct {
-				Type          string `json:"type"`
-				Reason        string `json:"reason"`
-				ProcessorType string `json:"processor_type"`
-			} `json:"suppressed,omitempty"`
-		} `json:"root_cause,omitempty"`
-		Type          string   `json:"type"`
-		Reason        string   `json:"reason"`
-		ProcessorType string   `json:"processor_type,omitempty"`
-		ScriptStack   []string `json:"script_stack,omitempty"`
-		Script        string   `json:"script,omitempty"`
-		Lang          string   `json:"lang,omitempty"`
+		RootCause     []RootCause `json:"root_cause,omitempty"`
+		Type          string      `json:"type"`
+		Reason        string      `json:"reason"`
+		ProcessorType string      `json:"processor_type,omitempty"`
+		ScriptStack   []string    `json:"script_stack,omitempty"`
+		Script        string      `json:"script,omitempty"`
+		Lang          string      `json:"lang,omitempty"`
 		Position      struct {
 			Offset int `json:"offset"`
 			Start  int `json:"start"`
 			End    int `json:"end"`
 		} `json:"position,omitempty"`
-		CausedBy struct {
-			Type     string `json:"type"`
-			Reason   string `json:"reason"`
-			CausedBy struct {
-				Type   string      `json:"type"`
-				Reason interface{} `json:"reason"`
-			} `json:"caused_by,omitempty"`
-		} `json:"caused_by,omitempty"`
+		CausedBy   *ErrorCause `json:"caused_by,omitempty"`
 		Suppressed []struct {
 			Type          string `json:"type"`
 			Reason        string `json:"reason"`
 			ProcessorType string `json:"processor_type"`
 		} `json:"suppressed,omitempty"`
+		Failures []ShardFailure `json:"failed_shards,omitempty"`
 	} `json:"error"`
 	Status int `json:"status"`
 }
 
+// HTTPStatusError is implemented by errors carrying the HTTP status code of the Elasticsearch response.
+type HTTPStatusError interface {
+	HTTPStatus() int
+}
+
+// HTTPStatus method returns the HTTP status code of the Elasticsearch response.
+func (e ErrorBody) HTTPStatus() int {
+	return e.Status
+}
+
+// retryableErrorTypes are Elasticsearch error types reported for transient failures.
+var retryableErrorTypes = map[string]struct{}{
+	"circuit_breaking_exception":      {},
+	"es_rejected_execution_exception": {},
+	"node_not_connected_exception":    {},
+	"too_many_requests":               {},
+	"unavailable_shards_exception":    {},
+}
+
+// IsRetryable method checks if the error is transient, so the request can be retried.
+func (e ErrorBody) IsRetryable() bool {
+	if e.Status == http.StatusTooManyRequests {
+		return true
+	}
+
+	if _, found := retryableErrorTypes[e.Error.Type]; found {
+		return true
+	}
+	for _, rootCause := range e.Error.RootCause {
+		if _, found := retryableErrorTypes[rootCause.Type]; found {
+			return true
+		}
+	}
+	return false
+}
+
+// ESError is the structured error returned by NewError. Use errors.As to inspect
+// the Elasticsearch error type, reason, root causes and shard failures.
+type ESError struct {
+	Type          string
+	Reason        string
+	Status        int
+	RootCauses    []RootCause
+	ShardFailures []ShardFailure
+
+	// CausedBy is the underlying cause reported by Elasticsearch, if any. It's returned by Unwrap,
+	// so the whole cause chain can be inspected with errors.As.
+	CausedBy *ESError
+}
+
+// Error returns the human-readable representation of the Elasticsearch error.
+func (e *ESError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "elasticsearch error (type=%v): %v", e.Type, e.Reason)
+	if len(e.RootCauses) > 0 {
+		rootCause, _ := json.MarshalIndent(e.RootCauses, "", "  ")
+		fmt.Fprintf(&sb, "\nRoot cause:\n%v", string(rootCause))
+	}
+	if len(e.ShardFailures) > 0 {
+		fmt.Fprintf(&sb, "\nShard failures (%d):", len(e.ShardFailures))
+		for _, failure := range e.ShardFailures {
+			fmt.Fprintf(&sb, "\n- index=%s shard=%d node=%s: %s (type=%s)", failure.Index, failure.Shard,
+				failure.Node, failure.Reason.Reason, failure.Reason.Type)
+		}
+	}
+	for cause := e.CausedBy; cause != nil; cause = cause.CausedBy {
+		fmt.Fprintf(&sb, "\nCaused by: %v (type=%v)", cause.Reason, cause.Type)
+	}
+	return sb.String()
+}
+
+// Unwrap returns the underlying cause of the Elasticsearch error, or nil if there is none.
+func (e *ESError) Unwrap() error {
+	if e.CausedBy == nil {
+		return nil
+	}
+	return e.CausedBy
+}
+
+// HTTPStatus method returns the HTTP status code of the Elasticsearch response.
+func (e *ESError) HTTPStatus() int {
+	return e.Status
+}
+
+// esError converts the response body into the structured error.
+func (e *ErrorBody) esError() *ESError {
+	return &ESError{
+		Type:          e.Error.Type,
+		Reason:        e.Error.Reason,
+		Status:        e.Status,
+		RootCauses:    e.Error.RootCause,
+		ShardFailures: e.Error.Failures,
+		CausedBy:      e.Error.CausedBy.esError(e.Status),
+	}
+}
+
+// esError converts the cause chain into structured errors with the status of the response.
+func (c *ErrorCause) esError(status int) *ESError {
+	if c == nil {
+		return nil
+	}
+	return &ESError{
+		Type:     c.Type,
+		Reason:   c.Reason,
+		Status:   status,
+		CausedBy: c.CausedBy.esError(status),
+	}
+}
+
+// Wrap method returns an error annotated with the message, which keeps the Elasticsearch error
+// in the chain. The returned error implements HTTPStatusError, and the *ESError can be extracted
+// with errors.As.
+func (e *ErrorBody) Wrap(outerMsg string) error {
+	return &wrappedError{msg: outerMsg, err: e.esError()}
+}
+
+// wrappedError annotates the Elasticsearch error with a message of the caller.
+type wrappedError struct {
+	msg string
+	err *ESError
+}
+
+func (w *wrappedError) Error() string {
+	return w.msg + ": " + w.err.Error()
+}
+
+func (w *wrappedError) Unwrap() error {
+	return w.err
+}
+
+func (w *wrappedError) HTTPStatus() int {
+	return w.err.HTTPStatus()
+}
+
+// NewError returns a new error constructed from the given response body.
+// This assumes the body contains a JSON encoded error. If the body can be parsed,
+// the returned error is an *ESError, otherwise an error is returned that contains the raw body.
 func NewError(body []byte) error {
 	var errBody ErrorBody
 	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&errBody); err == nil {
-		if len(errBody.Error.RootCause) > 0 {
-			rootCause, _ := json.MarshalIndent(errBody.Error.RootCause, "", "  ")
-			return fmt.Errorf("elasticsearch error (type=%v): %v\nRoot cause:\n%v", errBody.Error.Type,
-				errBody.Error.Reason, string(rootCause))
-		}
-		return fmt.Errorf("elasticsearch error (type=%v): %v", errBody.Error.Type, errBody.Error.Reason)
+		return errBody.esError()
 	}
 	// Fall back to including to raw body if it cannot be parsed.
 	return fmt.Errorf("elasticsearch error: %v", string(body))
 }
+
+// maxBulkFailuresInMessage limits the number of failed items included in the BulkError message.
+const maxBulkFailuresInMessage = 5
+
+// BulkItemFailure describes a single failed operation of the Bulk API request.
+type BulkItemFailure struct {
+	Operation string
+	Index     string
+	ID        string
+	Status    int
+	Type      string
+	Reason    string
+}
+
+// BulkError is returned by NewBulkError when some operations of the Bulk API request failed.
+type BulkError struct {
+	Total    int
+	Failures []BulkItemFailure
+}
+
+// Error returns the number of failed operations and a sample of their causes.
+func (e *BulkError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "elasticsearch bulk error: %d of %d operations failed", len(e.Failures), e.Total)
+	for i, failure := range e.Failures {
+		if i == maxBulkFailuresInMessage {
+			fmt.Fprintf(&sb, "\n- ... and %d more", len(e.Failures)-maxBulkFailuresInMessage)
+			break
+		}
+		fmt.Fprintf(&sb, "\n- %s index=%s id=%s status=%d: %s (type=%s)", failure.Operation, failure.Index,
+			failure.ID, failure.Status, failure.Reason, failure.Type)
+	}
+	return sb.String()
+}
+
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Index  string `json:"_index"`
+		ID     string `json:"_id"`
+		Status int    `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error,omitempty"`
+	} `json:"items"`
+}
+
+// NewBulkError returns a new error constructed from the Bulk API response body.
+// Bulk API responds with HTTP 200 also when some of the operations failed, so the response items
+// need to be checked. If the body can be parsed, the returned error is a *BulkError, or nil if no
+// operation failed.
+func NewBulkError(body []byte) error {
+	var resp bulkResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("elasticsearch bulk error: %v", string(body))
+	}
+	if !resp.Errors {
+		return nil
+	}
+
+	bulkErr := BulkError{Total: len(resp.Items)}
+	for _, item := range resp.Items {
+		for operation, result := range item {
+			if result.Error == nil {
+				continue
+			}
+			bulkErr.Failures = append(bulkErr.Failures, BulkItemFailure{
+				Operation: operation,
+				Index:     result.Index,
+				ID:        result.ID,
+				Status:    result.Status,
+				Type:      result.Error.Type,
+				Reason:    result.Error.Reason,
+			})
+		}
+	}
+	if len(bulkErr.Failures) == 0 {
+		return nil
+	}
+	return &bulkErr
+}
@@ -5,9 +5,12 @@
 package elasticsearch_test
 
 import (
+	"encoding/json"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/elastic-package/internal/elasticsearch"
 )
@@ -46,3 +49,195 @@ Root cause:
 	err := elasticsearch.NewError([]byte(resp))
 	assert.Equal(t, err.Error(), expected)
 }
+
+func TestNewErrorStructured(t *testing.T) {
+	const resp = `{
+  "error" : {
+    "root_cause" : [
+      {
+        "type" : "index_not_found_exception",
+        "reason" : "no such index [logs-foo]"
+      }
+    ],
+    "type" : "index_not_found_exception",
+    "reason" : "no such index [logs-foo]"
+  },
+  "status" : 404
+}`
+
+	err := errors.Wrap(elasticsearch.NewError([]byte(resp)), "request failed")
+
+	var esErr *elasticsearch.ESError
+	require.True(t, errors.As(err, &esErr))
+	assert.Equal(t, "index_not_found_exception", esErr.Type)
+	assert.Equal(t, "no such index [logs-foo]", esErr.Reason)
+	assert.Equal(t, 404, esErr.Status)
+
+	var statusErr elasticsearch.HTTPStatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, 404, statusErr.HTTPStatus())
+	require.Len(t, esErr.RootCauses, 1)
+	assert.Equal(t, "index_not_found_exception", esErr.RootCauses[0].Type)
+}
+
+func TestErrorBodyWrap(t *testing.T) {
+	const resp = `{"error":{"type":"resource_already_exists_exception","reason":"index [logs-foo] already exists"},"status":400}`
+
+	var errBody elasticsearch.ErrorBody
+	require.NoError(t, json.Unmarshal([]byte(resp), &errBody))
+
+	err := errors.Wrap(errBody.Wrap("creating index failed"), "setup failed")
+	assert.Equal(t, "setup failed: creating index failed: elasticsearch error (type=resource_already_exists_exception): index [logs-foo] already exists", err.Error())
+
+	var statusErr elasticsearch.HTTPStatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, 400, statusErr.HTTPStatus())
+
+	var esErr *elasticsearch.ESError
+	require.True(t, errors.As(err, &esErr))
+	assert.Equal(t, "resource_already_exists_exception", esErr.Type)
+}
+
+func TestNewErrorCausedBy(t *testing.T) {
+	const resp = `{
+  "error" : {
+    "type" : "illegal_argument_exception",
+    "reason" : "failed to parse painless script",
+    "caused_by" : {
+      "type" : "script_exception",
+      "reason" : "compile error",
+      "caused_by" : {
+        "type" : "illegal_argument_exception",
+        "reason" : "cannot resolve symbol [ctx.missing]"
+      }
+    }
+  },
+  "status" : 400
+}`
+
+	err := errors.Wrap(elasticsearch.NewError([]byte(resp)), "simulating pipeline failed")
+	assert.Contains(t, err.Error(), "\nCaused by: compile error (type=script_exception)\nCaused by: cannot resolve symbol [ctx.missing] (type=illegal_argument_exception)")
+
+	var esErr *elasticsearch.ESError
+	require.True(t, errors.As(err, &esErr))
+	assert.Equal(t, "illegal_argument_exception", esErr.Type)
+
+	var chain []string
+	for cause := error(esErr); cause != nil; cause = errors.Unwrap(cause) {
+		chain = append(chain, cause.(*elasticsearch.ESError).Type)
+	}
+	assert.Equal(t, []string{"illegal_argument_exception", "script_exception", "illegal_argument_exception"}, chain)
+}
+
+func TestNewErrorUnparseableBody(t *testing.T) {
+	err := elasticsearch.NewError([]byte("not json"))
+
+	var esErr *elasticsearch.ESError
+	assert.False(t, errors.As(err, &esErr))
+	assert.Equal(t, "elasticsearch error: not json", err.Error())
+}
+
+func TestErrorBodyIsRetryable(t *testing.T) {
+	cases := []struct {
+		title    string
+		body     string
+		expected bool
+	}{
+		{
+			title:    "rejected execution",
+			body:     `{"error":{"type":"es_rejected_execution_exception","reason":"rejected execution"},"status":429}`,
+			expected: true,
+		},
+		{
+			title:    "too many requests status",
+			body:     `{"error":{"type":"other_exception","reason":"busy"},"status":429}`,
+			expected: true,
+		},
+		{
+			title:    "retryable root cause",
+			body:     `{"error":{"root_cause":[{"type":"circuit_breaking_exception","reason":"data too large"}],"type":"search_phase_execution_exception","reason":"all shards failed"},"status":500}`,
+			expected: true,
+		},
+		{
+			title:    "parse exception",
+			body:     `{"error":{"type":"parse_exception","reason":"invalid processor"},"status":400}`,
+			expected: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			var errBody elasticsearch.ErrorBody
+			require.NoError(t, json.Unmarshal([]byte(c.body), &errBody))
+			assert.Equal(t, c.expected, errBody.IsRetryable())
+		})
+	}
+}
+
+func TestNewErrorShardFailures(t *testing.T) {
+	const resp = `{
+  "error" : {
+    "type" : "search_phase_execution_exception",
+    "reason" : "all shards failed",
+    "failed_shards" : [
+      {
+        "shard" : 0,
+        "index" : "logs-foo",
+        "node" : "node-1",
+        "reason" : {
+          "type" : "query_shard_exception",
+          "reason" : "failed to create query"
+        }
+      },
+      {
+        "shard" : 1,
+        "index" : "logs-foo",
+        "node" : "node-2",
+        "reason" : {
+          "type" : "illegal_argument_exception",
+          "reason" : "field [foo] doesn't exist"
+        }
+      }
+    ]
+  },
+  "status" : 400
+}`
+
+	const expected = `elasticsearch error (type=search_phase_execution_exception): all shards failed
+Shard failures (2):
+- index=logs-foo shard=0 node=node-1: failed to create query (type=query_shard_exception)
+- index=logs-foo shard=1 node=node-2: field [foo] doesn't exist (type=illegal_argument_exception)`
+
+	err := elasticsearch.NewError([]byte(resp))
+	assert.Equal(t, expected, err.Error())
+}
+
+func TestNewBulkError(t *testing.T) {
+	const resp = `{
+  "took": 30,
+  "errors": true,
+  "items": [
+    {"index": {"_index": "logs-test", "_id": "1", "status": 201, "result": "created"}},
+    {"index": {"_index": "logs-test", "_id": "2", "status": 400, "error": {"type": "mapper_parsing_exception", "reason": "failed to parse field [count]"}}},
+    {"delete": {"_index": "logs-test", "_id": "3", "status": 404, "result": "not_found"}},
+    {"create": {"_index": "logs-test", "_id": "4", "status": 409, "error": {"type": "version_conflict_engine_exception", "reason": "document already exists"}}}
+  ]
+}`
+
+	err := elasticsearch.NewBulkError([]byte(resp))
+
+	var bulkErr *elasticsearch.BulkError
+	require.True(t, errors.As(err, &bulkErr))
+	assert.Equal(t, 4, bulkErr.Total)
+	require.Len(t, bulkErr.Failures, 2)
+	assert.Equal(t, "index", bulkErr.Failures[0].Operation)
+	assert.Equal(t, "mapper_parsing_exception", bulkErr.Failures[0].Type)
+	assert.Equal(t, "create", bulkErr.Failures[1].Operation)
+	assert.Equal(t, 409, bulkErr.Failures[1].Status)
+	assert.Contains(t, err.Error(), "2 of 4 operations failed")
+}
+
+func TestNewBulkErrorNoFailures(t *testing.T) {
+	err := elasticsearch.NewBulkError([]byte(`{"took": 3, "errors": false, "items": [{"index": {"_id": "1", "status": 201}}]}`))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,275 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+
+	"github.com/elastic/elastic-package/internal/logger"
+)
+
+const (
+	defaultMaxAttempts    = 3
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 10 * time.Second
+
+	defaultFailureThreshold = 5
+	defaultCooldown         = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned by the RetryClient without sending the request, when Elasticsearch keeps failing.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// RetryClientConfig configures the RetryClient.
+type RetryClientConfig struct {
+	// MaxAttempts is the maximum number of attempts for a single request, including the first one.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry, it's doubled with every next attempt.
+	InitialBackoff time.Duration
+
+	// MaxBackoff limits the delay between attempts.
+	MaxBackoff time.Duration
+
+	// FailureThreshold is the number of consecutive attempts failing with transient errors, which opens
+	// the circuit breaker. Requests fail fast while the breaker is open.
+	FailureThreshold int
+
+	// Cooldown is the time the circuit breaker stays open, before a single trial request is let through.
+	Cooldown time.Duration
+
+	// Options are used to configure the underlying Elasticsearch client.
+	Options []ClientOption
+}
+
+// RetryClient wraps the Elasticsearch client and retries requests failing with transient errors.
+// If failures continue, the circuit breaker opens and requests fail fast with ErrCircuitOpen.
+type RetryClient struct {
+	client  *elasticsearch.Client
+	breaker *circuitBreaker
+
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+// NewRetryClient function creates new instance of the RetryClient.
+func NewRetryClient(cfg RetryClientConfig) (*RetryClient, error) {
+	// Requests are retried by RetryClient, so they're not retried by the transport too.
+	options := append([]ClientOption{}, cfg.Options...)
+	options = append(options, optionWithDisableRetry())
+	client, err := Client(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	rc := RetryClient{
+		client:         client,
+		maxAttempts:    cfg.MaxAttempts,
+		initialBackoff: cfg.InitialBackoff,
+		maxBackoff:     cfg.MaxBackoff,
+	}
+	if rc.maxAttempts <= 0 {
+		rc.maxAttempts = defaultMaxAttempts
+	}
+	if rc.initialBackoff <= 0 {
+		rc.initialBackoff = defaultInitialBackoff
+	}
+	if rc.maxBackoff <= 0 {
+		rc.maxBackoff = defaultMaxBackoff
+	}
+	rc.breaker = newCircuitBreaker(cfg.FailureThreshold, cfg.Cooldown)
+	return &rc, nil
+}
+
+// Index method stores the document in the index and returns the response body.
+func (c *RetryClient) Index(index string, document []byte) ([]byte, error) {
+	return c.perform("Index", func() (*esapi.Response, error) {
+		return c.client.Index(index, bytes.NewReader(document))
+	})
+}
+
+// Search method runs the search query against the index and returns the response body.
+func (c *RetryClient) Search(index string, query []byte) ([]byte, error) {
+	return c.perform("Search", func() (*esapi.Response, error) {
+		return c.client.Search(
+			c.client.Search.WithIndex(index),
+			c.client.Search.WithBody(bytes.NewReader(query)),
+		)
+	})
+}
+
+// Delete method removes the document from the index and returns the response body.
+func (c *RetryClient) Delete(index, documentID string) ([]byte, error) {
+	return c.perform("Delete", func() (*esapi.Response, error) {
+		return c.client.Delete(index, documentID)
+	})
+}
+
+// Bulk method sends the NDJSON body to the Bulk API and returns the response body.
+// If some of the operations failed, the response body is returned together with a *BulkError.
+func (c *RetryClient) Bulk(body []byte) ([]byte, error) {
+	respBody, err := c.perform("Bulk", func() (*esapi.Response, error) {
+		return c.client.Bulk(bytes.NewReader(body))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Bulk API responds with a success status also when some of the operations failed.
+	err = NewBulkError(respBody)
+	if err != nil {
+		return respBody, err
+	}
+	return respBody, nil
+}
+
+// perform method executes the request, retrying it when it fails with a transient error.
+// Request bodies are passed as byte slices, so they can be sent again.
+func (c *RetryClient) perform(name string, request func() (*esapi.Response, error)) ([]byte, error) {
+	var lastErr error
+	for attempt := 1; attempt <= c.maxAttempts; attempt++ {
+		if attempt > 1 {
+			backoff := c.backoff(attempt)
+			logger.Debugf("%s request failed (attempt %d/%d), retrying in %s: %v", name, attempt-1, c.maxAttempts, backoff, lastErr)
+			time.Sleep(backoff)
+		}
+
+		if !c.breaker.allow() {
+			return nil, errors.Wrapf(ErrCircuitOpen, "%s request failed", name)
+		}
+
+		body, retryable, err := c.performOnce(request)
+		// Permanent errors are reported by a responsive Elasticsearch, so only transient ones open the breaker.
+		c.breaker.record(!retryable)
+		if err == nil {
+			return body, nil
+		}
+		if !retryable {
+			return nil, errors.Wrapf(err, "%s request failed", name)
+		}
+		lastErr = err
+	}
+	return nil, errors.Wrapf(lastErr, "%s request failed after %d attempts", name, c.maxAttempts)
+}
+
+func (c *RetryClient) performOnce(request func() (*esapi.Response, error)) ([]byte, bool, error) {
+	resp, err := request()
+	if err != nil {
+		// Transport errors, like refused connections, are considered transient.
+		return nil, true, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, errors.Wrap(err, "can't read response body")
+	}
+
+	if !resp.IsError() {
+		return body, false, nil
+	}
+
+	retryable := resp.StatusCode == http.StatusTooManyRequests
+	var errBody ErrorBody
+	if err := json.Unmarshal(body, &errBody); err == nil && errBody.IsRetryable() {
+		retryable = true
+	}
+	return nil, retryable, NewError(body)
+}
+
+// backoff method returns the exponential delay before the given attempt, with random jitter.
+func (c *RetryClient) backoff(attempt int) time.Duration {
+	backoff := c.initialBackoff << (attempt - 2)
+	if backoff > c.maxBackoff || backoff <= 0 {
+		backoff = c.maxBackoff
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker opens after a number of consecutive failures. Once the cooldown passes, it lets a single
+// trial request through (half-open state), which either closes the breaker or opens it again.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		threshold = defaultFailureThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = defaultCooldown
+	}
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// allow method checks if the request can be sent.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = circuitHalfOpen
+		return true
+	case circuitHalfOpen:
+		// The trial request is in progress.
+		return false
+	default:
+		return true
+	}
+}
+
+// record method updates the breaker with the result of the request let through by allow.
+func (b *circuitBreaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		b.state = circuitClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.threshold {
+		logger.Debugf("Elasticsearch requests keep failing, circuit breaker is open for %s", b.cooldown)
+		b.state = circuitOpen
+		b.openedAt = b.now()
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package elasticsearch
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRetryClient(t *testing.T, statuses []int, body string) (*RetryClient, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		// Product check done by the client before the first request.
+		if r.Method == http.MethodGet && r.URL.Path == "/" {
+			w.Write([]byte(`{"version":{"number":"7.17.0","build_flavor":"default"},"tagline":"You Know, for Search"}`))
+			return
+		}
+
+		status := statuses[atomic.AddInt32(&requests, 1)-1]
+
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			w.Write([]byte(`{"result":"created"}`))
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewRetryClient(RetryClientConfig{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Options:        []ClientOption{OptionWithAddress(server.URL)},
+	})
+	require.NoError(t, err)
+	return client, &requests
+}
+
+func TestRetryClientRetriesTransientErrors(t *testing.T) {
+	client, requests := newTestRetryClient(t,
+		[]int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK},
+		`{"error":{"type":"es_rejected_execution_exception","reason":"rejected"},"status":429}`)
+
+	body, err := client.Index("logs-test", []byte(`{"message":"test"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"result":"created"}`, string(body))
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+}
+
+func TestRetryClientGivesUp(t *testing.T) {
+	client, requests := newTestRetryClient(t,
+		[]int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+		`{"error":{"type":"unavailable_shards_exception","reason":"primary shard is not active"},"status":503}`)
+
+	_, err := client.Search("logs-test", []byte(`{"query":{"match_all":{}}}`))
+	require.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+
+	var esErr *ESError
+	require.True(t, errors.As(err, &esErr))
+	assert.Equal(t, "unavailable_shards_exception", esErr.Type)
+}
+
+func TestRetryClientDoesNotRetryPermanentErrors(t *testing.T) {
+	client, requests := newTestRetryClient(t,
+		[]int{http.StatusNotFound},
+		`{"error":{"type":"index_not_found_exception","reason":"no such index [logs-test]"},"status":404}`)
+
+	_, err := client.Delete("logs-test", "1")
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+}
+
+func TestRetryClientCircuitBreaker(t *testing.T) {
+	client, requests := newTestRetryClient(t,
+		[]int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+		`{"error":{"type":"unavailable_shards_exception","reason":"primary shard is not active"},"status":503}`)
+
+	now := time.Now()
+	client.breaker = newCircuitBreaker(2, time.Minute)
+	client.breaker.now = func() time.Time { return now }
+
+	// The breaker opens after two failed attempts, so the third one isn't sent.
+	_, err := client.Search("logs-test", []byte(`{"query":{"match_all":{}}}`))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+
+	_, err = client.Search("logs-test", []byte(`{"query":{"match_all":{}}}`))
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+
+	// After the cooldown, the trial request closes the breaker.
+	now = now.Add(time.Minute)
+	_, err = client.Index("logs-test", []byte(`{"message":"test"}`))
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+}
+
+func TestRetryClientBulkItemFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodGet && r.URL.Path == "/" {
+			w.Write([]byte(`{"version":{"number":"7.17.0","build_flavor":"default"},"tagline":"You Know, for Search"}`))
+			return
+		}
+		w.Write([]byte(`{"took":3,"errors":true,"items":[
+			{"index":{"_index":"logs-test","_id":"1","status":201}},
+			{"index":{"_index":"logs-test","_id":"2","status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewRetryClient(RetryClientConfig{
+		MaxAttempts: 3,
+		Options:     []ClientOption{OptionWithAddress(server.URL)},
+	})
+	require.NoError(t, err)
+
+	body, err := client.Bulk([]byte("{\"index\":{\"_index\":\"logs-test\"}}\n{\"message\":\"test\"}\n"))
+	require.Error(t, err)
+	assert.NotEmpty(t, body)
+
+	var bulkErr *BulkError
+	require.True(t, errors.As(err, &bulkErr))
+	assert.Equal(t, 2, bulkErr.Total)
+	require.Len(t, bulkErr.Failures, 1)
+	assert.Equal(t, "mapper_parsing_exception", bulkErr.Failures[0].Type)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	breaker := newCircuitBreaker(3, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		require.True(t, breaker.allow())
+		breaker.record(false)
+	}
+	require.True(t, breaker.allow())
+	breaker.record(true)
+
+	// Failures need to be consecutive.
+	for i := 0; i < 3; i++ {
+		require.True(t, breaker.allow())
+		breaker.record(false)
+	}
+	assert.False(t, breaker.allow())
+
+	// Only a single trial request is let through when the cooldown passes.
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.allow())
+	assert.False(t, breaker.allow())
+
+	// Failed trial opens the breaker again.
+	breaker.record(false)
+	assert.False(t, breaker.allow())
+
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.allow())
+	breaker.record(true)
+	assert.True(t, breaker.allow())
+	assert.True(t, breaker.allow())
+}
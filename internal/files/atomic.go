@@ -0,0 +1,96 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package files
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// AtomicWrite method replaces the destination with a copy of the source, either a file or a directory.
+// The source is copied next to the destination first, so the destination is swapped only when the copy
+// is complete. Files are replaced atomically. Directories can't be replaced with a single rename, so
+// the existing destination directory is moved aside just before the copy takes its place.
+func AtomicWrite(dest, src string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return errors.Wrapf(err, "can't stat source (path: %s)", src)
+	}
+
+	// Temporary copy must be on the same filesystem as the destination to be renamed.
+	parent := filepath.Dir(dest)
+	err = os.MkdirAll(parent, 0755)
+	if err != nil {
+		return errors.Wrapf(err, "creating directory failed (path: %s)", parent)
+	}
+
+	if !info.IsDir() {
+		return atomicWriteFile(dest, src)
+	}
+	return atomicWriteDir(dest, src)
+}
+
+func atomicWriteFile(dest, src string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+"-")
+	if err != nil {
+		return errors.Wrap(err, "can't create temporary file")
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	err = CopyFile(src, tmp.Name())
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmp.Name(), dest)
+	if err != nil {
+		return errors.Wrapf(err, "can't replace file (path: %s)", dest)
+	}
+	return nil
+}
+
+func atomicWriteDir(dest, src string) error {
+	workDir, err := os.MkdirTemp(filepath.Dir(dest), "."+filepath.Base(dest)+"-")
+	if err != nil {
+		return errors.Wrap(err, "can't create temporary directory")
+	}
+	defer os.RemoveAll(workDir)
+
+	err = os.Chmod(workDir, 0755)
+	if err != nil {
+		return errors.Wrapf(err, "can't set permissions of directory %s", workDir)
+	}
+
+	err = CopyAll(src, workDir)
+	if err != nil {
+		return errors.Wrapf(err, "copying directory failed (path: %s)", src)
+	}
+
+	backupDir := workDir + ".old"
+	err = os.Rename(dest, backupDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrapf(err, "can't move existing directory aside (path: %s)", dest)
+	}
+	hasBackup := err == nil
+
+	err = os.Rename(workDir, dest)
+	if err != nil {
+		if hasBackup {
+			os.Rename(backupDir, dest)
+		}
+		return errors.Wrapf(err, "can't replace directory (path: %s)", dest)
+	}
+
+	if hasBackup {
+		err = os.RemoveAll(backupDir)
+		if err != nil {
+			return errors.Wrapf(err, "removing previous directory failed (path: %s)", backupDir)
+		}
+	}
+	return nil
+}
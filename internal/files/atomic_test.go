@@ -0,0 +1,88 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtomicWriteReplacesDirectory(t *testing.T) {
+	src := t.TempDir()
+	writeTestFile(t, filepath.Join(src, "manifest.yml"), "name: new\n")
+	writeTestFile(t, filepath.Join(src, "data_stream", "logs", "manifest.yml"), "title: logs\n")
+
+	parent := t.TempDir()
+	dest := filepath.Join(parent, "packages")
+	writeTestFile(t, filepath.Join(dest, "manifest.yml"), "name: old\n")
+	writeTestFile(t, filepath.Join(dest, "obsolete.yml"), "obsolete\n")
+
+	err := AtomicWrite(dest, src)
+	require.NoError(t, err)
+
+	assertFileContent(t, filepath.Join(dest, "manifest.yml"), "name: new\n")
+	assertFileContent(t, filepath.Join(dest, "data_stream", "logs", "manifest.yml"), "title: logs\n")
+	assert.NoFileExists(t, filepath.Join(dest, "obsolete.yml"))
+	assertDirEntries(t, parent, "packages")
+}
+
+func TestAtomicWriteCreatesDirectory(t *testing.T) {
+	src := t.TempDir()
+	writeTestFile(t, filepath.Join(src, "manifest.yml"), "name: new\n")
+
+	parent := filepath.Join(t.TempDir(), "stack")
+	dest := filepath.Join(parent, "packages")
+
+	err := AtomicWrite(dest, src)
+	require.NoError(t, err)
+
+	assertFileContent(t, filepath.Join(dest, "manifest.yml"), "name: new\n")
+	assertDirEntries(t, parent, "packages")
+}
+
+func TestAtomicWriteReplacesFile(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "kibana.yml")
+	writeTestFile(t, src, "server.name: new\n")
+
+	parent := t.TempDir()
+	dest := filepath.Join(parent, "kibana.yml")
+	writeTestFile(t, dest, "server.name: old\n")
+
+	err := AtomicWrite(dest, src)
+	require.NoError(t, err)
+
+	assertFileContent(t, dest, "server.name: new\n")
+	assertDirEntries(t, parent, "kibana.yml")
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func assertFileContent(t *testing.T, path, expected string) {
+	t.Helper()
+	body, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(body))
+}
+
+// assertDirEntries checks that temporary and previous copies have been cleaned up.
+func assertDirEntries(t *testing.T, dir string, expected ...string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, expected, names)
+}
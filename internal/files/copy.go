@@ -5,10 +5,11 @@
 package files
 
 import (
+	"io"
 	"os"
 	"path/filepath"
 
-	"github.com/magefile/mage/sh"
+	"github.com/pkg/errors"
 )
 
 // CopyAll method copies files from the source to the destination.
@@ -44,12 +45,45 @@ func copy(sourcePath, destinationPath string, skippedDirs []string) error {
 			return os.MkdirAll(filepath.Join(destinationPath, relativePath), 0755)
 		}
 
-		return sh.Copy(
-			filepath.Join(destinationPath, relativePath),
-			filepath.Join(sourcePath, relativePath))
+		return CopyFile(
+			filepath.Join(sourcePath, relativePath),
+			filepath.Join(destinationPath, relativePath))
 	})
 }
 
+// CopyFile method copies a single file from the source to the destination, preserving its permissions.
+// The destination file is overwritten if it exists.
+func CopyFile(sourcePath, destinationPath string) error {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return errors.Wrapf(err, "can't open source file %s", sourcePath)
+	}
+	defer source.Close()
+
+	info, err := source.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "can't stat source file %s", sourcePath)
+	}
+
+	destination, err := os.OpenFile(destinationPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return errors.Wrapf(err, "can't create destination file %s", destinationPath)
+	}
+	defer destination.Close()
+
+	_, err = io.Copy(destination, source)
+	if err != nil {
+		return errors.Wrapf(err, "can't copy file %s to %s", sourcePath, destinationPath)
+	}
+
+	// Permissions of the existing file are not changed by os.OpenFile.
+	err = destination.Chmod(info.Mode().Perm())
+	if err != nil {
+		return errors.Wrapf(err, "can't set permissions of file %s", destinationPath)
+	}
+	return destination.Close()
+}
+
 func shouldDirectoryBeSkipped(name string, skippedDirs []string) bool {
 	for _, d := range skippedDirs {
 		if name == d {
@@ -0,0 +1,35 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyFileOverwritesExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "run.sh")
+	require.NoError(t, os.WriteFile(src, []byte("#!/bin/sh\n"), 0755))
+	require.NoError(t, os.Chmod(src, 0755))
+
+	dest := filepath.Join(dir, "copy.sh")
+	require.NoError(t, os.WriteFile(dest, []byte("previous longer content\n"), 0600))
+
+	err := CopyFile(src, dest)
+	require.NoError(t, err)
+
+	body, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\n", string(body))
+
+	info, err := os.Stat(dest)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+}
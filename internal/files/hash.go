@@ -0,0 +1,63 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package files
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// HashDir function computes the SHA-256 hash of the directory tree, based on relative paths
+// and contents of all regular files. The hash is hex-encoded.
+func HashDir(dir string) (string, error) {
+	combined := sha256.New()
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		fileHash, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+
+		// filepath.Walk visits files in lexical order, so the hash is deterministic.
+		fmt.Fprintf(combined, "%s\x00%s\n", filepath.ToSlash(relativePath), fileHash)
+		return nil
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "can't hash directory %s", dir)
+	}
+	return hex.EncodeToString(combined.Sum(nil)), nil
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", errors.Wrapf(err, "can't read file %s", path)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,44 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashDir(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "manifest.yml"), "name: test\n")
+	writeTestFile(t, filepath.Join(dir, "data_stream", "logs", "manifest.yml"), "title: logs\n")
+
+	hash, err := HashDir(dir)
+	require.NoError(t, err)
+
+	same, err := HashDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, hash, same)
+
+	// The same tree in another location has the same hash.
+	copied := t.TempDir()
+	require.NoError(t, CopyAll(dir, copied))
+	copiedHash, err := HashDir(copied)
+	require.NoError(t, err)
+	assert.Equal(t, hash, copiedHash)
+
+	writeTestFile(t, filepath.Join(dir, "manifest.yml"), "name: changed\n")
+	changedContent, err := HashDir(dir)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changedContent)
+
+	require.NoError(t, os.Rename(filepath.Join(dir, "data_stream", "logs"), filepath.Join(dir, "data_stream", "metrics")))
+	changedPath, err := HashDir(dir)
+	require.NoError(t, err)
+	assert.NotEqual(t, changedContent, changedPath)
+}
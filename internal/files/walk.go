@@ -0,0 +1,38 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const packagesDir = "packages"
+
+// WalkPackages function calls fn for every package directory found in the "packages" directory
+// of the given root (e.g. root of the elastic/integrations repository). Regular files and hidden
+// directories are skipped. Walking stops at the first error returned by fn.
+func WalkPackages(root string, fn func(pkgDir string) error) error {
+	packagesPath := filepath.Join(root, packagesDir)
+	entries, err := os.ReadDir(packagesPath)
+	if err != nil {
+		return errors.Wrapf(err, "reading packages directory failed (path: %s)", packagesPath)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		err = fn(filepath.Join(packagesPath, entry.Name()))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
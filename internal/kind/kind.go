@@ -52,11 +52,9 @@ func ConnectToElasticStackNetwork() error {
 		return fmt.Errorf("expected single network description, got %d entries", len(networkDescriptions))
 	}
 
-	for _, c := range networkDescriptions[0].Containers {
-		if c.Name == ControlPlaneContainerName {
-			logger.Debugf("container %s is already attached to the %s network", ControlPlaneContainerName, stackNetwork)
-			return nil
-		}
+	if networkDescriptions[0].HasContainer(ControlPlaneContainerName) {
+		logger.Debugf("container %s is already attached to the %s network", ControlPlaneContainerName, stackNetwork)
+		return nil
 	}
 
 	logger.Debugf("attach %s container (ID: %s) to stack network %s", ControlPlaneContainerName, containerID, stackNetwork)
@@ -5,19 +5,75 @@
 package logger
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
+	"time"
 )
 
-var isDebugMode bool
+// Supported log formats.
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
+
+// Supported log levels.
+const (
+	DebugLevel = "debug"
+	InfoLevel  = "info"
+	WarnLevel  = "warn"
+	ErrorLevel = "error"
+)
+
+// Log levels ordered by verbosity, messages with lower severity than the selected level are dropped.
+const (
+	debugSeverity = iota
+	infoSeverity
+	warnSeverity
+	errorSeverity
+)
+
+var severities = map[string]int{
+	DebugLevel: debugSeverity,
+	InfoLevel:  infoSeverity,
+	WarnLevel:  warnSeverity,
+	ErrorLevel: errorSeverity,
+}
+
+var logSeverity = infoSeverity
+
+var logFormat = TextFormat
 
 // EnableDebugMode method enables verbose logging.
 func EnableDebugMode() {
-	isDebugMode = true
+	logSeverity = debugSeverity
 
 	Debug("Enable verbose logging")
 }
 
+// SetLevel method selects the minimum level of logged messages, one of "debug", "info" (default), "warn" or "error".
+func SetLevel(level string) error {
+	severity, found := severities[strings.ToLower(level)]
+	if !found {
+		return fmt.Errorf("unsupported log level: %s (available: %s, %s, %s, %s)", level, DebugLevel, InfoLevel, WarnLevel, ErrorLevel)
+	}
+	logSeverity = severity
+	return nil
+}
+
+// SetFormat method selects the format of log lines, either "text" (default) or "json".
+func SetFormat(format string) error {
+	switch format {
+	case TextFormat, JSONFormat:
+		logFormat = format
+		return nil
+	default:
+		return fmt.Errorf("unsupported log format: %s (available: %s, %s)", format, TextFormat, JSONFormat)
+	}
+}
+
 // Debug method logs message with "debug" level.
 func Debug(a ...interface{}) {
 	if !IsDebugMode() {
@@ -36,26 +92,38 @@ func Debugf(format string, a ...interface{}) {
 
 // IsDebugMode method checks if the debug mode is enabled.
 func IsDebugMode() bool {
-	return isDebugMode
+	return logSeverity == debugSeverity
 }
 
 // Info method logs message with "info" level.
 func Info(a ...interface{}) {
+	if logSeverity > infoSeverity {
+		return
+	}
 	logMessage("INFO", a...)
 }
 
 // Infof method logs message with "info" level and formats it.
 func Infof(format string, a ...interface{}) {
+	if logSeverity > infoSeverity {
+		return
+	}
 	logMessagef("INFO", format, a...)
 }
 
 // Warn method logs message with "warn" level.
 func Warn(a ...interface{}) {
+	if logSeverity > warnSeverity {
+		return
+	}
 	logMessage("WARN", a...)
 }
 
 // Warnf method logs message with "warn" level and formats it.
 func Warnf(format string, a ...interface{}) {
+	if logSeverity > warnSeverity {
+		return
+	}
 	logMessagef("WARN", format, a...)
 }
 
@@ -69,7 +137,51 @@ func Errorf(format string, a ...interface{}) {
 	logMessagef("ERROR", format, a...)
 }
 
+// Entry is a log entry enriched with key-value pairs.
+type Entry struct {
+	fields map[string]interface{}
+}
+
+// With method returns a log entry with the given key-value pairs attached.
+func With(fields map[string]interface{}) *Entry {
+	return &Entry{fields: fields}
+}
+
+// Debug method logs message with "debug" level.
+func (e *Entry) Debug(msg string) {
+	if !IsDebugMode() {
+		return
+	}
+	logFields("DEBUG", msg, e.fields)
+}
+
+// Info method logs message with "info" level.
+func (e *Entry) Info(msg string) {
+	if logSeverity > infoSeverity {
+		return
+	}
+	logFields("INFO", msg, e.fields)
+}
+
+// Warn method logs message with "warn" level.
+func (e *Entry) Warn(msg string) {
+	if logSeverity > warnSeverity {
+		return
+	}
+	logFields("WARN", msg, e.fields)
+}
+
+// Error method logs message with "error" level.
+func (e *Entry) Error(msg string) {
+	logFields("ERROR", msg, e.fields)
+}
+
 func logMessage(level string, a ...interface{}) {
+	if logFormat == JSONFormat {
+		logJSON(level, fmt.Sprint(a...), nil)
+		return
+	}
+
 	var all []interface{}
 	all = append(all, fmt.Sprintf("%5s ", level))
 	all = append(all, a...)
@@ -77,8 +189,51 @@ func logMessage(level string, a ...interface{}) {
 }
 
 func logMessagef(level string, format string, a ...interface{}) {
+	if logFormat == JSONFormat {
+		logJSON(level, fmt.Sprintf(format, a...), nil)
+		return
+	}
+
 	var all []interface{}
 	all = append(all, level)
 	all = append(all, a...)
 	log.Print(fmt.Sprintf("%5s "+format, all...))
 }
+
+func logFields(level, msg string, fields map[string]interface{}) {
+	if logFormat == JSONFormat {
+		logJSON(level, msg, fields)
+		return
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%5s %s", level, msg)
+	for _, key := range keys {
+		fmt.Fprintf(&sb, " %s=%v", key, fields[key])
+	}
+	log.Print(sb.String())
+}
+
+func logJSON(level, msg string, fields map[string]interface{}) {
+	doc := make(map[string]interface{}, len(fields)+3)
+	for key, value := range fields {
+		doc[key] = value
+	}
+	doc["@timestamp"] = time.Now().UTC().Format(time.RFC3339Nano)
+	doc["level"] = strings.ToLower(level)
+	doc["message"] = msg
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		log.Printf("ERROR can't marshal log entry: %v", err)
+		return
+	}
+	// Timestamp is already part of the document, so the standard log prefix is skipped.
+	fmt.Fprintln(log.Writer(), string(b))
+}
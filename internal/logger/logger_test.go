@@ -0,0 +1,71 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithJSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	require.NoError(t, SetFormat(JSONFormat))
+	defer SetFormat(TextFormat)
+
+	With(map[string]interface{}{"service": "kibana", "attempt": 2}).Info("service is not ready")
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "info", doc["level"])
+	assert.Equal(t, "service is not ready", doc["message"])
+	assert.Equal(t, "kibana", doc["service"])
+	assert.Equal(t, float64(2), doc["attempt"])
+	assert.Contains(t, doc, "@timestamp")
+}
+
+func TestWithTextFormat(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	With(map[string]interface{}{"service": "kibana", "attempt": 2}).Warn("service is not ready")
+
+	assert.Contains(t, buf.String(), " WARN service is not ready attempt=2 service=kibana\n")
+}
+
+func TestSetFormatUnsupported(t *testing.T) {
+	assert.Error(t, SetFormat("xml"))
+}
+
+func TestSetLevel(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	require.NoError(t, SetLevel(WarnLevel))
+	defer SetLevel(InfoLevel)
+
+	Info("dropped message")
+	Warn("logged message")
+	assert.NotContains(t, buf.String(), "dropped message")
+	assert.Contains(t, buf.String(), " WARN logged message\n")
+	assert.False(t, IsDebugMode())
+
+	require.NoError(t, SetLevel("DEBUG"))
+	assert.True(t, IsDebugMode())
+}
+
+func TestSetLevelUnsupported(t *testing.T) {
+	assert.Error(t, SetLevel("trace"))
+}
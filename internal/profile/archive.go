@@ -0,0 +1,115 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package profile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mholt/archiver/v3"
+	"github.com/pkg/errors"
+
+	"github.com/elastic/elastic-package/internal/configuration/locations"
+	"github.com/elastic/elastic-package/internal/logger"
+)
+
+// ExportProfile creates a .tar.gz archive of the profile from the default elastic-package config dir.
+func ExportProfile(profileName, destPath string) error {
+	loc, err := locations.NewLocationManager()
+	if err != nil {
+		return errors.Wrap(err, "error finding stack dir location")
+	}
+	return exportProfile(loc.ProfileDir(), profileName, destPath)
+}
+
+// ImportProfile extracts the profile archive into the default elastic-package config dir.
+// An existing profile with the same name is replaced.
+func ImportProfile(archivePath string) error {
+	loc, err := locations.NewLocationManager()
+	if err != nil {
+		return errors.Wrap(err, "error finding stack dir location")
+	}
+	return importProfile(loc.ProfileDir(), archivePath)
+}
+
+// exportProfile archives the given profile, the archive contains a single top-level directory named after the profile.
+func exportProfile(elasticPackagePath, profileName, destPath string) error {
+	profile, err := loadProfile(elasticPackagePath, profileName)
+	if err != nil {
+		return errors.Wrapf(err, "error loading profile %s", profileName)
+	}
+
+	logger.Debugf("Archive profile %s (destination: %s)", profileName, destPath)
+	tgz := archiver.NewTarGz()
+	tgz.OverwriteExisting = true
+	err = tgz.Archive([]string{profile.ProfilePath}, destPath)
+	if err != nil {
+		return errors.Wrapf(err, "can't archive profile %s", profileName)
+	}
+	return nil
+}
+
+// importProfile extracts the archive to a work directory and validates its content,
+// before replacing the profile at the given package path location.
+func importProfile(elasticPackagePath, archivePath string) error {
+	err := os.MkdirAll(elasticPackagePath, 0755)
+	if err != nil {
+		return errors.Wrapf(err, "error creating profiles directory %s", elasticPackagePath)
+	}
+
+	// Work directory is placed next to profiles, so it can be moved with os.Rename.
+	workDir, err := os.MkdirTemp(elasticPackagePath, ".import-")
+	if err != nil {
+		return errors.Wrap(err, "can't prepare a work directory")
+	}
+	defer os.RemoveAll(workDir)
+
+	logger.Debugf("Extract profile archive %s (work directory: %s)", archivePath, workDir)
+	err = archiver.NewTarGz().Unarchive(archivePath, workDir)
+	if err != nil {
+		return errors.Wrapf(err, "can't extract profile archive %s", archivePath)
+	}
+
+	entries, err := os.ReadDir(workDir)
+	if err != nil {
+		return errors.Wrapf(err, "error reading from directory %s", workDir)
+	}
+	if len(entries) != 1 || !entries[0].IsDir() {
+		return fmt.Errorf("expected a single profile directory in archive %s", archivePath)
+	}
+	profileName := entries[0].Name()
+
+	_, err = loadProfile(workDir, profileName)
+	if err != nil {
+		return errors.Wrapf(err, "archive %s doesn't contain a valid profile", archivePath)
+	}
+
+	profilePath := filepath.Join(elasticPackagePath, profileName)
+	err = os.RemoveAll(profilePath)
+	if err != nil {
+		return errors.Wrapf(err, "error removing existing profile %s", profileName)
+	}
+	err = os.Rename(filepath.Join(workDir, profileName), profilePath)
+	if err != nil {
+		return errors.Wrapf(err, "error moving profile %s", profileName)
+	}
+
+	// Profile metadata contains the absolute path, which is different on the target machine.
+	profile, err := loadProfile(elasticPackagePath, profileName)
+	if err != nil {
+		return errors.Wrapf(err, "error loading profile %s", profileName)
+	}
+	meta, err := profile.metadata()
+	if err != nil {
+		return errors.Wrap(err, "error reading profile metadata")
+	}
+	meta.Path = profilePath
+	err = profile.updateMetadata(meta)
+	if err != nil {
+		return errors.Wrap(err, "error updating metadata")
+	}
+	return nil
+}
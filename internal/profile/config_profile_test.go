@@ -80,3 +80,120 @@ func cleanupProfile(t *testing.T, dir string) {
 	err := os.RemoveAll(dir)
 	assert.NoErrorf(t, err, "Error cleaning up tempdir %s", dir)
 }
+
+func TestExportImportProfile(t *testing.T) {
+	elasticPackageDir, err := os.MkdirTemp("", "package")
+	defer cleanupProfile(t, elasticPackageDir)
+	assert.NoError(t, err, "error creating tempdir")
+
+	err = createProfile(Options{
+		PackagePath: elasticPackageDir,
+		Name:        profileName,
+	})
+	assert.NoErrorf(t, err, "error creating profile %s", err)
+
+	archivePath := filepath.Join(elasticPackageDir, "profile.tar.gz")
+	err = exportProfile(elasticPackageDir, profileName, archivePath)
+	assert.NoErrorf(t, err, "error exporting profile %s", err)
+
+	importDir := filepath.Join(elasticPackageDir, "imported")
+	err = importProfile(importDir, archivePath)
+	assert.NoErrorf(t, err, "error importing profile %s", err)
+
+	imported, err := loadProfile(importDir, profileName)
+	assert.NoErrorf(t, err, "error loading imported profile %s", err)
+	meta, err := imported.metadata()
+	assert.NoErrorf(t, err, "error reading imported metadata %s", err)
+	assert.Equal(t, filepath.Join(importDir, profileName), meta.Path)
+}
+
+func TestValidateProfile(t *testing.T) {
+	elasticPackageDir := t.TempDir()
+
+	options := Options{
+		PackagePath: elasticPackageDir,
+		Name:        profileName,
+	}
+	err := createProfile(options)
+	assert.NoError(t, err)
+
+	err = validateProfile(elasticPackageDir, profileName)
+	assert.NoError(t, err)
+
+	profile, err := loadProfile(elasticPackageDir, profileName)
+	assert.NoError(t, err)
+	err = os.WriteFile(profile.FetchPath(SnapshotFile), []byte("services: [unclosed"), 0644)
+	assert.NoError(t, err)
+
+	err = validateProfile(elasticPackageDir, profileName)
+	assert.Error(t, err)
+}
+
+func TestValidateEnvFile(t *testing.T) {
+	assert.NoError(t, validateEnvFile("# comment\nFLEET_ENROLL=1\n\nFLEET_URL=http://fleet-server:8220\n"))
+	assert.Error(t, validateEnvFile("FLEET_ENROLL=1\n"))
+	assert.Error(t, validateEnvFile("FLEET_ENROLL=1\nFLEET_URL\n"))
+}
+
+func TestDiffProfiles(t *testing.T) {
+	elasticPackageDir := t.TempDir()
+
+	for _, name := range []string{"profile_a", "profile_b"} {
+		err := createProfile(Options{PackagePath: elasticPackageDir, Name: name})
+		assert.NoError(t, err)
+	}
+
+	diffs, err := diffProfiles(elasticPackageDir, "profile_a", "profile_b")
+	assert.NoError(t, err)
+	assert.Empty(t, diffs)
+
+	profileB, err := loadProfile(elasticPackageDir, "profile_b")
+	assert.NoError(t, err)
+	err = os.WriteFile(profileB.FetchPath(ElasticAgentDefaultEnvFile), []byte("FLEET_ENROLL=0\nFLEET_URL=http://fleet-server:8220\n"), 0644)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(profileB.ProfileStackPath, "override.yml"), []byte("version: '2.3'\n"), 0644)
+	assert.NoError(t, err)
+
+	diffs, err = diffProfiles(elasticPackageDir, "profile_a", "profile_b")
+	assert.NoError(t, err)
+	if assert.Len(t, diffs, 2) {
+		assert.Equal(t, string(ElasticAgentDefaultEnvFile), diffs[0].File)
+		assert.True(t, diffs[0].Changed)
+		assert.Contains(t, diffs[0].Diff, "+FLEET_ENROLL=0")
+		assert.Equal(t, "override.yml", diffs[1].File)
+		assert.True(t, diffs[1].OnlyInB)
+	}
+}
+
+func TestCopyProfile(t *testing.T) {
+	elasticPackageDir := t.TempDir()
+
+	err := createProfile(Options{PackagePath: elasticPackageDir, Name: profileName})
+	assert.NoError(t, err)
+
+	source, err := loadProfile(elasticPackageDir, profileName)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(source.ProfileStackPath, "override.yml"), []byte("version: '2.3'\n"), 0644)
+	assert.NoError(t, err)
+
+	err = copyProfile(elasticPackageDir, profileName, "test_copy", false)
+	assert.NoError(t, err)
+
+	copied, err := loadProfile(elasticPackageDir, "test_copy")
+	assert.NoError(t, err)
+	meta, err := copied.metadata()
+	assert.NoError(t, err)
+	assert.Equal(t, "test_copy", meta.Name)
+	assert.Equal(t, filepath.Join(elasticPackageDir, "test_copy"), meta.Path)
+	assert.FileExists(t, filepath.Join(copied.ProfileStackPath, "override.yml"))
+
+	diffs, err := diffProfiles(elasticPackageDir, profileName, "test_copy")
+	assert.NoError(t, err)
+	assert.Empty(t, diffs)
+
+	err = copyProfile(elasticPackageDir, profileName, "test_copy", false)
+	assert.Error(t, err)
+
+	err = copyProfile(elasticPackageDir, profileName, "test_copy", true)
+	assert.NoError(t, err)
+}
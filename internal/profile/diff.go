@@ -0,0 +1,105 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/kylelemons/godebug/diff"
+	"github.com/pkg/errors"
+
+	"github.com/elastic/elastic-package/internal/configuration/locations"
+)
+
+// FileDiff describes the difference of a single stack file between two profiles.
+type FileDiff struct {
+	// File is the path relative to the stack directory of the profile.
+	File string
+
+	OnlyInA bool
+	OnlyInB bool
+	Changed bool
+
+	// Diff is the line diff of the file content, set if the file changed.
+	Diff string
+}
+
+// DiffProfiles compares stack files (Docker Compose files, service configs and environment files)
+// of two profiles from the default elastic-package config dir. Only differing files are returned.
+func DiffProfiles(nameA, nameB string) ([]FileDiff, error) {
+	loc, err := locations.NewLocationManager()
+	if err != nil {
+		return nil, errors.Wrap(err, "error finding stack dir location")
+	}
+	return diffProfiles(loc.ProfileDir(), nameA, nameB)
+}
+
+func diffProfiles(elasticPackagePath, nameA, nameB string) ([]FileDiff, error) {
+	filesA, err := readProfileStackFiles(elasticPackagePath, nameA)
+	if err != nil {
+		return nil, err
+	}
+	filesB, err := readProfileStackFiles(elasticPackagePath, nameB)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []FileDiff
+	for name, bodyA := range filesA {
+		bodyB, found := filesB[name]
+		switch {
+		case !found:
+			diffs = append(diffs, FileDiff{File: name, OnlyInA: true})
+		case bodyA != bodyB:
+			diffs = append(diffs, FileDiff{File: name, Changed: true, Diff: diff.Diff(bodyA, bodyB)})
+		}
+	}
+	for name := range filesB {
+		if _, found := filesA[name]; !found {
+			diffs = append(diffs, FileDiff{File: name, OnlyInB: true})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].File < diffs[j].File
+	})
+	return diffs, nil
+}
+
+// readProfileStackFiles returns contents of all files in the stack directory of the profile, by relative path.
+func readProfileStackFiles(elasticPackagePath, profileName string) (map[string]string, error) {
+	profile, err := loadProfile(elasticPackagePath, profileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading profile %s", profileName)
+	}
+
+	stackFiles := map[string]string{}
+	err = filepath.Walk(profile.ProfileStackPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(profile.ProfileStackPath, path)
+		if err != nil {
+			return err
+		}
+
+		body, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		stackFiles[filepath.ToSlash(relativePath)] = string(body)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading stack files of profile %s", profileName)
+	}
+	return stackFiles, nil
+}
@@ -5,13 +5,17 @@
 package profile
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/elastic/elastic-package/internal/configuration/locations"
+	"github.com/elastic/elastic-package/internal/files"
 	"github.com/elastic/elastic-package/internal/logger"
 )
 
@@ -73,6 +77,16 @@ func DeleteProfile(profileName string) error {
 	return deleteProfile(loc.ProfileDir(), profileName)
 }
 
+// CopyProfile duplicates an existing profile in the default elastic-package config dir, including
+// all files of the profile directory. An existing destination profile is replaced only if overwrite is set.
+func CopyProfile(src, dst string, overwrite bool) error {
+	loc, err := locations.NewLocationManager()
+	if err != nil {
+		return errors.Wrap(err, "error finding stack dir location")
+	}
+	return copyProfile(loc.ProfileDir(), src, dst, overwrite)
+}
+
 // FetchAllProfiles returns a list of profile values
 func FetchAllProfiles(elasticPackagePath string) ([]Metadata, error) {
 	dirList, err := os.ReadDir(elasticPackagePath)
@@ -102,6 +116,45 @@ func FetchAllProfiles(elasticPackagePath string) ([]Metadata, error) {
 	return profiles, nil
 }
 
+// ProfileInfo describes the profile available in the default elastic-package config dir.
+type ProfileInfo struct {
+	Name      string    `json:"name"`
+	Path      string    `json:"path"`
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"date_created"`
+	User      string    `json:"user"`
+	Version   string    `json:"version"`
+}
+
+// ListProfiles returns profiles from the default elastic-package config dir, sorted by name.
+func ListProfiles() ([]ProfileInfo, error) {
+	loc, err := locations.NewLocationManager()
+	if err != nil {
+		return nil, errors.Wrap(err, "error finding stack dir location")
+	}
+
+	profiles, err := FetchAllProfiles(loc.ProfileDir())
+	if err != nil {
+		return nil, errors.Wrap(err, "error fetching all profiles")
+	}
+
+	var infos []ProfileInfo
+	for _, meta := range profiles {
+		infos = append(infos, ProfileInfo{
+			Name:      meta.Name,
+			Path:      meta.Path,
+			IsDefault: meta.Name == DefaultProfile,
+			CreatedAt: meta.DateCreated,
+			User:      meta.User,
+			Version:   meta.Version,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+	return infos, nil
+}
+
 // createProfile installs a new profile at the given package path location.
 // overwriteExisting determines the behavior if a profile with the given name already exists.
 // On true, it'll overwrite the profile, on false, it'll backup the existing profile to profilename_VERSION-DATE-CREATED
@@ -216,6 +269,72 @@ func updateExistingDefaultProfile(path string) error {
 	return nil
 }
 
+// copyProfile copies a given config profile. The profile directory is copied to a work directory first,
+// so the destination profile is replaced only when the copy is complete.
+func copyProfile(elasticPackagePath, src, dst string, overwrite bool) error {
+	if src == dst {
+		return errors.New("source and destination profiles must be different")
+	}
+
+	srcProfile, err := loadProfile(elasticPackagePath, src)
+	if err != nil {
+		return errors.Wrapf(err, "error loading profile %s", src)
+	}
+
+	dstPath := filepath.Join(elasticPackagePath, dst)
+	_, err = os.Stat(dstPath)
+	if err == nil && !overwrite {
+		return fmt.Errorf("profile %s already exists", dst)
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrapf(err, "error checking profile %s", dst)
+	}
+
+	workDir, err := os.MkdirTemp(elasticPackagePath, ".copy-")
+	if err != nil {
+		return errors.Wrap(err, "can't prepare a work directory")
+	}
+	defer os.RemoveAll(workDir)
+	// Temporary directories are only accessible by the owner, profile directories are not.
+	err = os.Chmod(workDir, 0755)
+	if err != nil {
+		return errors.Wrap(err, "can't set permissions of the work directory")
+	}
+
+	logger.Debugf("Copy profile %s to %s", src, dst)
+	err = files.CopyAll(srcProfile.ProfilePath, workDir)
+	if err != nil {
+		return errors.Wrapf(err, "error copying profile %s", src)
+	}
+
+	// Metadata is updated before moving the copy, profiles are only loaded when their name matches it.
+	meta, err := srcProfile.metadata()
+	if err != nil {
+		return errors.Wrap(err, "error reading profile metadata")
+	}
+	meta.Name = dst
+	meta.Path = dstPath
+	meta.DateCreated = time.Now()
+	metaString, err := json.Marshal(meta)
+	if err != nil {
+		return errors.Wrap(err, "error marshalling metadata json")
+	}
+	err = os.WriteFile(filepath.Join(workDir, string(PackageProfileMetaFile)), metaString, 0664)
+	if err != nil {
+		return errors.Wrap(err, "error writing metadata file")
+	}
+
+	err = os.RemoveAll(dstPath)
+	if err != nil {
+		return errors.Wrapf(err, "error removing existing profile %s", dst)
+	}
+	err = os.Rename(workDir, dstPath)
+	if err != nil {
+		return errors.Wrapf(err, "error moving profile %s", dst)
+	}
+	return nil
+}
+
 // deleteProfile deletes a given config profile.
 func deleteProfile(elasticPackagePath string, profileName string) error {
 	if profileName == DefaultProfile {
@@ -0,0 +1,101 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package profile
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/elastic/elastic-package/internal/configuration/locations"
+)
+
+// yamlProfileFiles are the profile files expected to contain valid YAML.
+var yamlProfileFiles = []configFile{
+	SnapshotFile,
+	KibanaConfigDefaultFile,
+	KibanaConfig8xFile,
+	ElasticsearchConfigDefaultFile,
+	ElasticsearchConfig8xFile,
+	PackageRegistryConfigFile,
+}
+
+// envProfileFiles are the profile .env files.
+var envProfileFiles = []configFile{
+	ElasticAgentDefaultEnvFile,
+	ElasticAgent8xEnvFile,
+}
+
+// requiredAgentEnvVars are the environment variables Elastic Agent needs to enroll in Fleet.
+var requiredAgentEnvVars = []string{"FLEET_ENROLL", "FLEET_URL"}
+
+// ValidateProfile checks that the profile from the default elastic-package config dir is complete
+// and its files have valid content.
+func ValidateProfile(profileName string) error {
+	loc, err := locations.NewLocationManager()
+	if err != nil {
+		return errors.Wrap(err, "error finding stack dir location")
+	}
+	return validateProfile(loc.ProfileDir(), profileName)
+}
+
+func validateProfile(elasticPackagePath, profileName string) error {
+	profile, err := loadProfile(elasticPackagePath, profileName)
+	if errors.Is(err, ErrNotAProfile) {
+		return fmt.Errorf("%s is not a valid profile, profile metadata is missing", profileName)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "profile %s is incomplete, you can recreate it with \"elastic-package profiles create\"", profileName)
+	}
+
+	for _, name := range yamlProfileFiles {
+		var content interface{}
+		err := yaml.Unmarshal([]byte(profile.configFiles[name].body), &content)
+		if err != nil {
+			return errors.Wrapf(err, "profile %s is invalid, file %s doesn't contain valid YAML", profileName, profile.FetchPath(name))
+		}
+	}
+
+	for _, name := range envProfileFiles {
+		err := validateEnvFile(profile.configFiles[name].body)
+		if err != nil {
+			return errors.Wrapf(err, "profile %s is invalid, file %s", profileName, profile.FetchPath(name))
+		}
+	}
+	return nil
+}
+
+// validateEnvFile checks that every line is a KEY=VALUE pair and that required variables are defined.
+func validateEnvFile(body string) error {
+	defined := map[string]struct{}{}
+
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		key := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || key == "" {
+			return fmt.Errorf("line %d is not a KEY=VALUE pair", lineNumber)
+		}
+		defined[key] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "can't read environment variables")
+	}
+
+	for _, name := range requiredAgentEnvVars {
+		if _, found := defined[name]; !found {
+			return fmt.Errorf("required environment variable %s is not defined", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// benchmarkIndex is the index used to store synthetic documents, it's removed after the benchmark.
+const benchmarkIndex = "elastic-package-benchmark"
+
+// benchmarkRequestTimeout is the timeout of a single request sent to Elasticsearch during the benchmark.
+const benchmarkRequestTimeout = 60 * time.Second
+
+// BenchmarkResult describes the indexing throughput measured by Benchmark.
+type BenchmarkResult struct {
+	TotalDocs     int
+	DurationMs    int64
+	DocsPerSecond float64
+}
+
+// Benchmark function indexes synthetic documents into Elasticsearch of the running stack, in batches
+// using the Bulk API, and measures the indexing throughput.
+func Benchmark(options Options, docs int, batchSize int) (BenchmarkResult, error) {
+	if docs <= 0 || batchSize <= 0 {
+		return BenchmarkResult{}, fmt.Errorf("number of documents and batch size must be positive (docs: %d, batch size: %d)", docs, batchSize)
+	}
+
+	envVars, err := EnvVars(options)
+	if err != nil {
+		return BenchmarkResult{}, errors.Wrap(err, "can't read stack environment variables")
+	}
+	client := benchmarkClient{
+		host:     envVars[ElasticsearchHostEnv],
+		username: envVars[ElasticsearchUsernameEnv],
+		password: envVars[ElasticsearchPasswordEnv],
+		client:   &http.Client{Timeout: benchmarkRequestTimeout},
+	}
+	return runBenchmark(client, docs, batchSize)
+}
+
+// runBenchmark function indexes synthetic documents using the given client and measures the indexing throughput.
+func runBenchmark(client benchmarkClient, docs int, batchSize int) (BenchmarkResult, error) {
+	err := client.deleteIndex()
+	if err != nil {
+		return BenchmarkResult{}, errors.Wrap(err, "can't remove benchmark index")
+	}
+	defer client.deleteIndex()
+
+	start := time.Now()
+	for indexed := 0; indexed < docs; indexed += batchSize {
+		batch := batchSize
+		if docs-indexed < batch {
+			batch = docs - indexed
+		}
+
+		err = client.bulkIndex(benchmarkBatch(indexed, batch))
+		if err != nil {
+			return BenchmarkResult{}, errors.Wrapf(err, "indexing documents failed (indexed: %d)", indexed)
+		}
+	}
+	duration := time.Since(start)
+
+	return BenchmarkResult{
+		TotalDocs:     docs,
+		DurationMs:    duration.Milliseconds(),
+		DocsPerSecond: float64(docs) / duration.Seconds(),
+	}, nil
+}
+
+// benchmarkBatch returns the Bulk API request body with the given number of synthetic documents.
+func benchmarkBatch(first, count int) []byte {
+	var buf bytes.Buffer
+	timestamp := time.Now().UTC().Format(time.RFC3339Nano)
+	for i := first; i < first+count; i++ {
+		fmt.Fprintf(&buf, "{\"index\":{\"_index\":%q}}\n", benchmarkIndex)
+		fmt.Fprintf(&buf, "{\"@timestamp\":%q,\"message\":\"benchmark document %d\",\"value\":%d}\n", timestamp, i, i)
+	}
+	return buf.Bytes()
+}
+
+// benchmarkClient is a minimal Elasticsearch client. The elasticsearch package can't be used here,
+// as it depends on this package.
+type benchmarkClient struct {
+	host     string
+	username string
+	password string
+	client   *http.Client
+}
+
+func (c benchmarkClient) bulkIndex(body []byte) error {
+	respBody, err := c.do(http.MethodPost, "/_bulk", body)
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		Errors bool `json:"errors"`
+	}
+	err = json.Unmarshal(respBody, &resp)
+	if err != nil {
+		return errors.Wrap(err, "can't unmarshal Bulk API response")
+	}
+	if resp.Errors {
+		return errors.New("some documents were rejected by Elasticsearch")
+	}
+	return nil
+}
+
+func (c benchmarkClient) deleteIndex() error {
+	_, err := c.do(http.MethodDelete, "/"+benchmarkIndex+"?ignore_unavailable=true", nil)
+	return err
+}
+
+func (c benchmarkClient) do(method, path string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, c.host+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create request")
+	}
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "request %s %s failed", method, path)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read response body")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status for %s %s (%s): %s", method, path, resp.Status, string(respBody))
+	}
+	return respBody, nil
+}
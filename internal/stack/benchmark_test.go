@@ -0,0 +1,91 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stack
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBenchmark(t *testing.T) {
+	var mu sync.Mutex
+	var bulkRequests []int
+	var deleteRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		username, password, _ := r.BasicAuth()
+		assert.Equal(t, "elastic", username)
+		assert.Equal(t, "changeme", password)
+
+		switch {
+		case r.Method == http.MethodDelete && r.URL.Path == "/"+benchmarkIndex:
+			deleteRequests++
+			w.Write([]byte(`{"acknowledged":true}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/_bulk":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			bulkRequests = append(bulkRequests, bytes.Count(body, []byte("\n"))/2)
+			w.Write([]byte(`{"errors":false}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := benchmarkClient{
+		host:     server.URL,
+		username: "elastic",
+		password: "changeme",
+		client:   server.Client(),
+	}
+	result, err := runBenchmark(client, 25, 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, 25, result.TotalDocs)
+	assert.Equal(t, []int{10, 10, 5}, bulkRequests)
+	assert.Equal(t, 2, deleteRequests)
+}
+
+func TestRunBenchmarkRejectedDocuments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_bulk" {
+			w.Write([]byte(`{"errors":true}`))
+			return
+		}
+		w.Write([]byte(`{"acknowledged":true}`))
+	}))
+	defer server.Close()
+
+	client := benchmarkClient{host: server.URL, client: server.Client()}
+	_, err := runBenchmark(client, 5, 5)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "some documents were rejected")
+}
+
+func TestRunBenchmarkTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	httpClient := server.Client()
+	httpClient.Timeout = 50 * time.Millisecond
+	client := benchmarkClient{host: server.URL, client: httpClient}
+	_, err := runBenchmark(client, 5, 5)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "can't remove benchmark index")
+}
@@ -5,19 +5,41 @@
 package stack
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 
 	"github.com/elastic/elastic-package/internal/builder"
+	"github.com/elastic/elastic-package/internal/common"
+	"github.com/elastic/elastic-package/internal/compose"
 	"github.com/elastic/elastic-package/internal/configuration/locations"
+	"github.com/elastic/elastic-package/internal/docker"
 	"github.com/elastic/elastic-package/internal/files"
+	"github.com/elastic/elastic-package/internal/logger"
+	"github.com/elastic/elastic-package/internal/profile"
 )
 
 // DockerComposeProjectName is the name of the Docker Compose project used to boot up
 // Elastic Stack containers.
 const DockerComposeProjectName = "elastic-package-stack"
 
+// stackBuildFile is the file in the stack directory describing the last build of the stack images.
+// All profiles share the same Docker Compose project, so they share built images too.
+const stackBuildFile = DockerComposeProjectName + "-build.json"
+
+// stackBuild describes the last build of the stack images.
+type stackBuild struct {
+	InputsHash string `json:"inputs_hash"`
+	ImageID    string `json:"image_id"`
+}
+
 // BootUp function boots up the Elastic stack.
 func BootUp(options Options) error {
 	buildPackagesPath, found, err := builder.FindBuildPackagesDirectory()
@@ -30,43 +52,235 @@ func BootUp(options Options) error {
 		return errors.Wrap(err, "locating stack packages directory failed")
 	}
 
-	err = files.ClearDir(stackPackagesDir.PackagesDir())
-	if err != nil {
-		return errors.Wrap(err, "clearing package contents failed")
-	}
-
 	if found {
-		fmt.Printf("Custom build packages directory found: %s\n", buildPackagesPath)
-		err = files.CopyAll(buildPackagesPath, stackPackagesDir.PackagesDir())
+		if options.ProgressFunc == nil {
+			fmt.Printf("Custom build packages directory found: %s\n", buildPackagesPath)
+		}
+		// Packages are replaced at once, so the running package registry doesn't observe an empty directory.
+		err = files.AtomicWrite(stackPackagesDir.PackagesDir(), buildPackagesPath)
 		if err != nil {
 			return errors.Wrap(err, "copying package contents failed")
 		}
+	} else {
+		err = files.ClearDir(stackPackagesDir.PackagesDir())
+		if err != nil {
+			return errors.Wrap(err, "clearing package contents failed")
+		}
 	}
 
-	fmt.Println("Packages from the following directories will be loaded into the package-registry:")
-	fmt.Println("- built-in packages (package-storage:snapshot Docker image)")
+	if options.ProgressFunc == nil {
+		fmt.Println("Packages from the following directories will be loaded into the package-registry:")
+		fmt.Println("- built-in packages (package-storage:snapshot Docker image)")
 
-	if found {
-		fmt.Printf("- %s\n", buildPackagesPath)
+		if found {
+			fmt.Printf("- %s\n", buildPackagesPath)
+		}
 	}
+	options.reportProgress("packages prepared", 0.2)
 
-	err = dockerComposeBuild(options)
+	if options.NoPull {
+		err = checkCachedImages(options)
+		if err != nil {
+			return errors.Wrap(err, "checking cached images failed")
+		}
+	} else {
+		err = pullStackImages(options, true)
+		if err != nil {
+			return errors.Wrap(err, "pulling stack images failed")
+		}
+	}
+
+	err = buildStackImages(options, stackPackagesDir)
 	if err != nil {
-		return errors.Wrap(err, "building docker images failed")
+		return err
 	}
+	options.reportProgress("image build complete", 0.5)
 
 	err = dockerComposeUp(options)
 	if err != nil {
 		return errors.Wrap(err, "running docker-compose failed")
 	}
+
+	err = saveRunningOptions(options)
+	if err != nil {
+		return errors.Wrap(err, "storing options of the running stack failed")
+	}
+
+	if !options.Wait {
+		options.reportProgress("containers started", 1)
+		return nil
+	}
+	options.reportProgress("containers started", 0.8)
+
+	err = WaitForStack(options, options.WaitTimeout)
+	if err != nil {
+		return errors.Wrap(err, "waiting for the stack failed")
+	}
+	options.reportProgress("health checks passing", 1)
 	return nil
 }
 
+// buildStackImages function builds the stack images, unless the package-registry image has been built
+// from the same inputs and hasn't been replaced since.
+func buildStackImages(options Options, loc *locations.LocationManager) error {
+	baseImageID, err := imageID(options, profile.PackageRegistryBaseImage)
+	if err != nil {
+		return errors.Wrap(err, "inspecting package-registry base image failed")
+	}
+
+	inputsHash, err := stackBuildHash(options, loc.PackagesDir(), baseImageID)
+	if err != nil {
+		return errors.Wrap(err, "calculating hash of stack build inputs failed")
+	}
+
+	c, err := compose.NewProject(DockerComposeProjectName, composeFiles(options)...)
+	if err != nil {
+		return errors.Wrap(err, "could not create docker compose project")
+	}
+	builtImage := c.ImageName("package-registry")
+	builtImageID, err := imageID(options, builtImage)
+	if err != nil {
+		return errors.Wrap(err, "inspecting package-registry image failed")
+	}
+
+	buildPath := filepath.Join(loc.StackDir(), stackBuildFile)
+	var lastBuild stackBuild
+	body, err := os.ReadFile(buildPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, "reading description of the last stack build failed")
+	}
+	if err == nil {
+		err = json.Unmarshal(body, &lastBuild)
+		if err != nil {
+			return errors.Wrapf(err, "unmarshalling description of the last stack build failed (path: %s)", buildPath)
+		}
+	}
+
+	if builtImageID != "" && lastBuild.InputsHash == inputsHash && lastBuild.ImageID == builtImageID {
+		logger.Debugf("Packages and stack configuration didn't change since the last build, skipping build of docker images")
+		return nil
+	}
+
+	err = dockerComposeBuild(options)
+	if err != nil {
+		return errors.Wrap(err, "building docker images failed")
+	}
+
+	builtImageID, err = imageID(options, builtImage)
+	if err != nil {
+		return errors.Wrap(err, "inspecting package-registry image failed")
+	}
+	body, err = json.Marshal(stackBuild{InputsHash: inputsHash, ImageID: builtImageID})
+	if err != nil {
+		return errors.Wrap(err, "marshalling description of the stack build failed")
+	}
+	err = os.WriteFile(buildPath, body, 0644)
+	if err != nil {
+		return errors.Wrap(err, "storing description of the stack build failed")
+	}
+	return nil
+}
+
+// imageID function returns the ID of the image present in the Docker daemon running the stack,
+// or an empty string if the image isn't present.
+func imageID(options Options, imageRef string) (string, error) {
+	exists, err := docker.ImageExistsWithContext(options.dockerContext(), imageRef)
+	if err != nil || !exists {
+		return "", err
+	}
+
+	image, err := docker.InspectImageWithContext(options.dockerContext(), imageRef)
+	if err != nil {
+		return "", err
+	}
+	return image.ID, nil
+}
+
+// stackBuildHash function returns the hash of inputs used to build the stack images: the stack version,
+// selected services, packages served by the package registry, the stack configuration of the profile,
+// override files, extra environment variables and the ID of the package-registry base image.
+func stackBuildHash(options Options, packagesDir, baseImageID string) (string, error) {
+	packagesHash, err := files.HashDir(packagesDir)
+	if err != nil {
+		return "", errors.Wrap(err, "can't hash packages")
+	}
+
+	configHash, err := files.HashDir(options.Profile.ProfileStackPath)
+	if err != nil {
+		return "", errors.Wrap(err, "can't hash stack configuration")
+	}
+
+	services := append([]string{}, options.Services...)
+	sort.Strings(services)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n%s\n%s\n", options.StackVersion, strings.Join(services, ","), packagesHash, configHash, baseImageID)
+	for _, env := range options.extraEnvs() {
+		fmt.Fprintf(h, "%s\n", env)
+	}
+	for _, overrideFile := range options.OverrideFiles {
+		body, err := os.ReadFile(overrideFile)
+		if err != nil {
+			return "", errors.Wrapf(err, "can't read override file %s", overrideFile)
+		}
+		fmt.Fprintf(h, "%s\x00%s\n", overrideFile, body)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 // TearDown function takes down the testing stack.
 func TearDown(options Options) error {
+	var preservedVolumes []string
+	if options.PreserveVolumes {
+		var err error
+		preservedVolumes, err = stackVolumes(options)
+		if err != nil {
+			return errors.Wrap(err, "listing stack volumes failed")
+		}
+	}
+
 	err := dockerComposeDown(options)
 	if err != nil {
 		return errors.Wrap(err, "stopping docker containers failed")
 	}
+
+	err = removeRunningOptions()
+	if err != nil {
+		return errors.Wrap(err, "removing options of the running stack failed")
+	}
+
+	if len(preservedVolumes) > 0 {
+		fmt.Println("The following volumes have been preserved:")
+		for _, volume := range preservedVolumes {
+			fmt.Printf("- %s\n", volume)
+		}
+	}
 	return nil
 }
+
+// stackVolumes function returns names of Docker volumes mounted in the stack containers.
+func stackVolumes(options Options) ([]string, error) {
+	containerIDs, err := dockerComposeContainerIDs(options)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read container IDs")
+	}
+	if len(containerIDs) == 0 {
+		return nil, nil
+	}
+
+	descriptions, err := docker.InspectContainersWithContext(options.dockerContext(), containerIDs...)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't inspect stack containers")
+	}
+
+	var volumes []string
+	for _, description := range descriptions {
+		for _, mount := range description.Mounts {
+			if mount.Type == "volume" && !common.StringSliceContains(volumes, mount.Name) {
+				volumes = append(volumes, mount.Name)
+			}
+		}
+	}
+	sort.Strings(volumes)
+	return volumes, nil
+}
@@ -0,0 +1,57 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stack
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-package/internal/profile"
+)
+
+func TestStackBuildHash(t *testing.T) {
+	packagesDir := t.TempDir()
+	overrideFile := filepath.Join(t.TempDir(), "override.yml")
+	err := os.WriteFile(overrideFile, []byte("version: '2.3'\n"), 0644)
+	require.NoError(t, err)
+
+	options := Options{
+		StackVersion:  "8.1.0",
+		OverrideFiles: []string{overrideFile},
+		ExtraEnv:      map[string]string{"ES_JAVA_OPTS": "-Xmx1g"},
+		Profile:       &profile.Profile{ProfileStackPath: t.TempDir()},
+	}
+	hash, err := stackBuildHash(options, packagesDir, "sha256:base")
+	require.NoError(t, err)
+
+	same, err := stackBuildHash(options, packagesDir, "sha256:base")
+	require.NoError(t, err)
+	assert.Equal(t, hash, same)
+
+	newBase, err := stackBuildHash(options, packagesDir, "sha256:newer")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, newBase)
+
+	options.ExtraEnv = map[string]string{"ES_JAVA_OPTS": "-Xmx2g"}
+	otherEnv, err := stackBuildHash(options, packagesDir, "sha256:base")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, otherEnv)
+
+	err = os.WriteFile(overrideFile, []byte("version: '2.4'\n"), 0644)
+	require.NoError(t, err)
+	otherOverride, err := stackBuildHash(options, packagesDir, "sha256:base")
+	require.NoError(t, err)
+	assert.NotEqual(t, otherEnv, otherOverride)
+
+	err = os.WriteFile(filepath.Join(packagesDir, "manifest.yml"), []byte("name: test\n"), 0644)
+	require.NoError(t, err)
+	otherPackages, err := stackBuildHash(options, packagesDir, "sha256:base")
+	require.NoError(t, err)
+	assert.NotEqual(t, otherOverride, otherPackages)
+}
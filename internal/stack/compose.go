@@ -6,6 +6,7 @@ package stack
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -36,8 +37,14 @@ func (eb *envBuilder) build() []string {
 	return eb.vars
 }
 
+// composeFiles returns the profile snapshot file, followed by override files selected by the user.
+func composeFiles(options Options) []string {
+	files := []string{options.Profile.FetchPath(profile.SnapshotFile)}
+	return append(files, options.OverrideFiles...)
+}
+
 func dockerComposeBuild(options Options) error {
-	c, err := compose.NewProject(DockerComposeProjectName, options.Profile.FetchPath(profile.SnapshotFile))
+	c, err := compose.NewProject(DockerComposeProjectName, composeFiles(options)...)
 	if err != nil {
 		return errors.Wrap(err, "could not create docker compose project")
 	}
@@ -52,6 +59,8 @@ func dockerComposeBuild(options Options) error {
 			withEnvs(appConfig.StackImageRefs(options.StackVersion).AsEnv()).
 			withEnv(stackVariantAsEnv(options.StackVersion)).
 			withEnvs(options.Profile.ComposeEnvVars()).
+			withEnvs(options.extraEnvs()).
+			withEnvs(options.dockerHostEnvs()).
 			build(),
 		Services: withIsReadyServices(withDependentServices(options.Services)),
 	}
@@ -63,7 +72,7 @@ func dockerComposeBuild(options Options) error {
 }
 
 func dockerComposePull(options Options) error {
-	c, err := compose.NewProject(DockerComposeProjectName, options.Profile.FetchPath(profile.SnapshotFile))
+	c, err := compose.NewProject(DockerComposeProjectName, composeFiles(options)...)
 	if err != nil {
 		return errors.Wrap(err, "could not create docker compose project")
 	}
@@ -78,6 +87,8 @@ func dockerComposePull(options Options) error {
 			withEnvs(appConfig.StackImageRefs(options.StackVersion).AsEnv()).
 			withEnv(stackVariantAsEnv(options.StackVersion)).
 			withEnvs(options.Profile.ComposeEnvVars()).
+			withEnvs(options.extraEnvs()).
+			withEnvs(options.dockerHostEnvs()).
 			build(),
 		Services: withIsReadyServices(withDependentServices(options.Services)),
 	}
@@ -89,7 +100,7 @@ func dockerComposePull(options Options) error {
 }
 
 func dockerComposeUp(options Options) error {
-	c, err := compose.NewProject(DockerComposeProjectName, options.Profile.FetchPath(profile.SnapshotFile))
+	c, err := compose.NewProject(DockerComposeProjectName, composeFiles(options)...)
 	if err != nil {
 		return errors.Wrap(err, "could not create docker compose project")
 	}
@@ -109,6 +120,8 @@ func dockerComposeUp(options Options) error {
 			withEnvs(appConfig.StackImageRefs(options.StackVersion).AsEnv()).
 			withEnv(stackVariantAsEnv(options.StackVersion)).
 			withEnvs(options.Profile.ComposeEnvVars()).
+			withEnvs(options.extraEnvs()).
+			withEnvs(options.dockerHostEnvs()).
 			build(),
 		ExtraArgs: args,
 		Services:  withIsReadyServices(withDependentServices(options.Services)),
@@ -121,7 +134,7 @@ func dockerComposeUp(options Options) error {
 }
 
 func dockerComposeDown(options Options) error {
-	c, err := compose.NewProject(DockerComposeProjectName, options.Profile.FetchPath(profile.SnapshotFile))
+	c, err := compose.NewProject(DockerComposeProjectName, composeFiles(options)...)
 	if err != nil {
 		return errors.Wrap(err, "could not create docker compose project")
 	}
@@ -136,9 +149,14 @@ func dockerComposeDown(options Options) error {
 			withEnvs(appConfig.StackImageRefs(options.StackVersion).AsEnv()).
 			withEnv(stackVariantAsEnv(options.StackVersion)).
 			withEnvs(options.Profile.ComposeEnvVars()).
+			withEnvs(options.extraEnvs()).
+			withEnvs(options.dockerHostEnvs()).
 			build(),
+		ExtraArgs: []string{"--remove-orphans"},
+	}
+	if !options.PreserveVolumes {
 		// Remove associated volumes.
-		ExtraArgs: []string{"--volumes", "--remove-orphans"},
+		downOptions.ExtraArgs = append(downOptions.ExtraArgs, "--volumes")
 	}
 	if err := c.Down(downOptions); err != nil {
 		return errors.Wrap(err, "running command failed")
@@ -146,6 +164,111 @@ func dockerComposeDown(options Options) error {
 	return nil
 }
 
+func dockerComposeRestart(options Options, services []string) error {
+	c, err := compose.NewProject(DockerComposeProjectName, composeFiles(options)...)
+	if err != nil {
+		return errors.Wrap(err, "could not create docker compose project")
+	}
+
+	appConfig, err := install.Configuration()
+	if err != nil {
+		return errors.Wrap(err, "can't read application configuration")
+	}
+
+	opts := compose.CommandOptions{
+		Env: newEnvBuilder().
+			withEnvs(appConfig.StackImageRefs(options.StackVersion).AsEnv()).
+			withEnv(stackVariantAsEnv(options.StackVersion)).
+			withEnvs(options.Profile.ComposeEnvVars()).
+			withEnvs(options.extraEnvs()).
+			withEnvs(options.dockerHostEnvs()).
+			build(),
+		Services: services,
+	}
+
+	if err := c.Restart(opts); err != nil {
+		return errors.Wrap(err, "running command failed")
+	}
+	return nil
+}
+
+func dockerComposeScale(options Options, service string, replicas int) error {
+	c, err := compose.NewProject(DockerComposeProjectName, composeFiles(options)...)
+	if err != nil {
+		return errors.Wrap(err, "could not create docker compose project")
+	}
+
+	appConfig, err := install.Configuration()
+	if err != nil {
+		return errors.Wrap(err, "can't read application configuration")
+	}
+
+	env := newEnvBuilder().
+		withEnvs(appConfig.StackImageRefs(options.StackVersion).AsEnv()).
+		withEnv(stackVariantAsEnv(options.StackVersion)).
+		withEnvs(options.Profile.ComposeEnvVars()).
+		withEnvs(options.extraEnvs()).
+		withEnvs(options.dockerHostEnvs()).
+		build()
+
+	if replicas > 1 {
+		config, err := c.Config(compose.CommandOptions{Env: env})
+		if err != nil {
+			return errors.Wrap(err, "could not get Docker Compose configuration")
+		}
+
+		// Replicas can't publish the same host port.
+		var hostPorts []string
+		for _, port := range config.Services[service].Ports {
+			if port.ExternalPort > 0 {
+				hostPorts = append(hostPorts, fmt.Sprintf("%s:%d", port.ExternalIP, port.ExternalPort))
+			}
+		}
+		if len(hostPorts) > 0 {
+			return fmt.Errorf("service %s publishes fixed host ports (%s), it can't be scaled to more than one replica",
+				service, strings.Join(hostPorts, ", "))
+		}
+	}
+
+	opts := compose.CommandOptions{
+		Env:       env,
+		ExtraArgs: []string{"-d", "--no-recreate", "--scale", fmt.Sprintf("%s=%d", service, replicas)},
+		Services:  []string{service},
+	}
+
+	if err := c.Up(opts); err != nil {
+		return errors.Wrap(err, "running command failed")
+	}
+	return nil
+}
+
+func dockerComposeContainerIDs(options Options, services ...string) ([]string, error) {
+	c, err := compose.NewProject(DockerComposeProjectName, composeFiles(options)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create docker compose project")
+	}
+
+	appConfig, err := install.Configuration()
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read application configuration")
+	}
+
+	containerIDs, err := c.ContainerIDs(compose.CommandOptions{
+		Env: newEnvBuilder().
+			withEnvs(appConfig.StackImageRefs(options.StackVersion).AsEnv()).
+			withEnv(stackVariantAsEnv(options.StackVersion)).
+			withEnvs(options.Profile.ComposeEnvVars()).
+			withEnvs(options.extraEnvs()).
+			withEnvs(options.dockerHostEnvs()).
+			build(),
+		Services: services,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "running command failed")
+	}
+	return containerIDs, nil
+}
+
 func withDependentServices(services []string) []string {
 	for _, aService := range services {
 		if aService == "elastic-agent" {
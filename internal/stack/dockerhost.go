@@ -0,0 +1,60 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/elastic/elastic-package/internal/docker"
+)
+
+// ValidateDockerHost function checks if the host is a Docker daemon address, e.g. tcp://host:2376,
+// ssh://user@host or unix:///var/run/docker.sock.
+func ValidateDockerHost(host string) error {
+	u, err := url.Parse(host)
+	if err != nil {
+		return err
+	}
+
+	switch u.Scheme {
+	case "tcp", "ssh":
+		if u.Hostname() == "" {
+			return fmt.Errorf("missing host name")
+		}
+	case "unix", "npipe":
+		if u.Path == "" {
+			return fmt.Errorf("missing socket path")
+		}
+	default:
+		return fmt.Errorf("unsupported scheme %q (supported: tcp, ssh, unix, npipe)", u.Scheme)
+	}
+	return nil
+}
+
+// dockerContext returns the context selecting the Docker daemon running the stack for docker commands.
+func (o Options) dockerContext() context.Context {
+	return docker.WithHost(context.Background(), o.RemoteDockerHost)
+}
+
+// dockerHostEnvs returns environment variables selecting the Docker daemon running the stack
+// for docker-compose commands.
+func (o Options) dockerHostEnvs() []string {
+	if o.RemoteDockerHost == "" {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s=%s", docker.HostEnv, o.RemoteDockerHost)}
+}
+
+// serviceHost returns the host exposing ports published on the given IP address by the stack services.
+// Ports published by a remote Docker daemon are exposed by the remote host.
+func (o Options) serviceHost(publishedIP string) string {
+	u, err := url.Parse(o.RemoteDockerHost)
+	if err != nil || (u.Scheme != "tcp" && u.Scheme != "ssh") || u.Hostname() == "" {
+		return publishedIP
+	}
+	return u.Hostname()
+}
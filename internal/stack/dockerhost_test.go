@@ -0,0 +1,39 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDockerHost(t *testing.T) {
+	for _, host := range []string{"tcp://10.0.0.5:2376", "ssh://user@build-host", "unix:///var/run/docker.sock", "npipe:////./pipe/docker_engine"} {
+		assert.NoError(t, ValidateDockerHost(host), host)
+	}
+	for _, host := range []string{"10.0.0.5:2376", "http://10.0.0.5:2376", "tcp://", "unix://"} {
+		assert.Error(t, ValidateDockerHost(host), host)
+	}
+}
+
+func TestServiceHost(t *testing.T) {
+	cases := map[string]string{
+		"":                            "127.0.0.1",
+		"unix:///var/run/docker.sock": "127.0.0.1",
+		"tcp://10.0.0.5:2376":         "10.0.0.5",
+		"ssh://user@build-host":       "build-host",
+	}
+
+	for host, expected := range cases {
+		options := Options{RemoteDockerHost: host}
+		assert.Equal(t, expected, options.serviceHost("127.0.0.1"), host)
+	}
+}
+
+func TestDockerHostEnvs(t *testing.T) {
+	assert.Empty(t, Options{}.dockerHostEnvs())
+	assert.Equal(t, []string{"DOCKER_HOST=tcp://10.0.0.5:2376"}, Options{RemoteDockerHost: "tcp://10.0.0.5:2376"}.dockerHostEnvs())
+}
@@ -24,7 +24,12 @@ var observedServices = []string{"elasticsearch", elasticAgentService, fleetServe
 
 // DumpOptions defines dumping options for Elatic stack data.
 type DumpOptions struct {
-	Output  string
+	Output string
+
+	// RemoteDockerHost selects the Docker daemon running the stack, the one the stack has been
+	// booted up with is used if empty.
+	RemoteDockerHost string
+
 	Profile *profile.Profile
 }
 
@@ -52,19 +57,25 @@ func dumpStackLogs(options DumpOptions) error {
 		return errors.Wrapf(err, "can't create output location (path: %s)", logsPath)
 	}
 
-	snapshotPath := options.Profile.FetchPath(profile.SnapshotFile)
+	stackOptions, err := RunningOptions(options.Profile)
+	if err != nil {
+		return errors.Wrap(err, "can't read options of the running stack")
+	}
+	if options.RemoteDockerHost != "" {
+		stackOptions.RemoteDockerHost = options.RemoteDockerHost
+	}
 
 	for _, serviceName := range observedServices {
 		logger.Debugf("Dump stack logs for %s", serviceName)
 
-		content, err := dockerComposeLogs(serviceName, snapshotPath)
+		content, err := dockerComposeLogs(serviceName, stackOptions)
 		if err != nil {
 			logger.Errorf("can't fetch service logs (service: %s): %v", serviceName, err)
 		} else {
 			writeLogFiles(logsPath, serviceName, content)
 		}
 
-		err = copyDockerInternalLogs(serviceName, logsPath)
+		err = copyDockerInternalLogs(serviceName, logsPath, stackOptions)
 		if err != nil {
 			logger.Errorf("can't copy internal logs (service: %s): %v", serviceName, err)
 		}
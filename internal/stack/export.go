@@ -0,0 +1,117 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stack
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/elastic/elastic-package/internal/docker"
+)
+
+// stackExport is the description of the running Elastic stack written by Export.
+type stackExport struct {
+	StackVersion string            `yaml:"stack_version,omitempty"`
+	Services     []exportedService `yaml:"services"`
+}
+
+type exportedService struct {
+	Name        string            `yaml:"name"`
+	Container   string            `yaml:"container"`
+	Image       string            `yaml:"image"`
+	ImageDigest string            `yaml:"image_digest,omitempty"`
+	Status      string            `yaml:"status"`
+	Networks    map[string]string `yaml:"networks,omitempty"`
+}
+
+// Export function writes the YAML description of the running Elastic stack to the destination file.
+// It contains the stack version, services with their images and image digests, and IP addresses
+// of service containers in the Docker networks.
+func Export(options Options, destPath string) error {
+	containerIDs, err := dockerComposeContainerIDs(options)
+	if err != nil {
+		return errors.Wrap(err, "can't read container IDs")
+	}
+	if len(containerIDs) == 0 {
+		return errors.New("there are no Elastic stack services running")
+	}
+
+	descriptions, err := docker.InspectContainersWithContext(options.dockerContext(), containerIDs...)
+	if err != nil {
+		return errors.Wrap(err, "can't inspect stack containers")
+	}
+
+	images := make(map[string]docker.ImageDescription)
+	for _, description := range descriptions {
+		if _, found := images[description.Config.Image]; found {
+			continue
+		}
+		image, err := docker.InspectImageWithContext(options.dockerContext(), description.Config.Image)
+		if err != nil {
+			return errors.Wrapf(err, "can't inspect image of container %s", description.Name)
+		}
+		images[description.Config.Image] = image
+	}
+	return writeStackExport(destPath, descriptions, images)
+}
+
+// writeStackExport function writes the YAML description of the stack containers, using descriptions
+// of their images, to the destination file.
+func writeStackExport(destPath string, descriptions []docker.ContainerDescription, images map[string]docker.ImageDescription) error {
+	var export stackExport
+	for _, description := range descriptions {
+		service := exportedService{
+			Name:      description.Config.Labels[composeServiceLabel],
+			Container: strings.TrimPrefix(description.Name, "/"),
+			Image:     description.Config.Image,
+			Status:    description.State.Status,
+		}
+
+		image := images[description.Config.Image]
+		service.ImageDigest = image.ID
+		if len(image.RepoDigests) > 0 {
+			service.ImageDigest = image.RepoDigests[0]
+		}
+
+		for network, settings := range description.NetworkSettings.Networks {
+			if service.Networks == nil {
+				service.Networks = map[string]string{}
+			}
+			service.Networks[network] = settings.IPAddress
+		}
+
+		if service.Name == "elasticsearch" {
+			export.StackVersion = imageTag(service.Image)
+		}
+		export.Services = append(export.Services, service)
+	}
+	sort.Slice(export.Services, func(i, j int) bool {
+		return export.Services[i].Container < export.Services[j].Container
+	})
+
+	body, err := yaml.Marshal(export)
+	if err != nil {
+		return errors.Wrap(err, "can't marshal stack description")
+	}
+
+	err = os.WriteFile(destPath, body, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "can't write stack description to %s", destPath)
+	}
+	return nil
+}
+
+// imageTag returns the tag of the image reference, e.g. "8.1.0" for "docker.elastic.co/elasticsearch/elasticsearch:8.1.0".
+func imageTag(imageRef string) string {
+	name := imageRef[strings.LastIndex(imageRef, "/")+1:]
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		return name[i+1:]
+	}
+	return ""
+}
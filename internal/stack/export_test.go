@@ -0,0 +1,88 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stack
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/elastic/elastic-package/internal/docker"
+)
+
+const inspectedContainers = `[
+  {
+    "ID": "b2f1",
+    "Name": "/elastic-package-stack_kibana_1",
+    "Config": {
+      "Image": "docker.elastic.co/kibana/kibana:8.1.0",
+      "Labels": {"com.docker.compose.service": "kibana"}
+    },
+    "NetworkSettings": {"Networks": {"elastic-package-stack_default": {"IPAddress": "172.18.0.3"}}},
+    "State": {"Status": "running"}
+  },
+  {
+    "ID": "a1e0",
+    "Name": "/elastic-package-stack_elasticsearch_1",
+    "Config": {
+      "Image": "docker.elastic.co/elasticsearch/elasticsearch:8.1.0",
+      "Labels": {"com.docker.compose.service": "elasticsearch"}
+    },
+    "NetworkSettings": {"Networks": {"elastic-package-stack_default": {"IPAddress": "172.18.0.2"}}},
+    "State": {"Status": "running"}
+  }
+]`
+
+func TestWriteStackExport(t *testing.T) {
+	var descriptions []docker.ContainerDescription
+	err := json.Unmarshal([]byte(inspectedContainers), &descriptions)
+	require.NoError(t, err)
+
+	images := map[string]docker.ImageDescription{
+		"docker.elastic.co/elasticsearch/elasticsearch:8.1.0": {
+			ID:          "sha256:es",
+			RepoDigests: []string{"docker.elastic.co/elasticsearch/elasticsearch@sha256:es"},
+		},
+		"docker.elastic.co/kibana/kibana:8.1.0": {
+			ID: "sha256:kibana",
+		},
+	}
+
+	destPath := filepath.Join(t.TempDir(), "elastic-stack.yml")
+	err = writeStackExport(destPath, descriptions, images)
+	require.NoError(t, err)
+
+	body, err := os.ReadFile(destPath)
+	require.NoError(t, err)
+
+	var export stackExport
+	err = yaml.Unmarshal(body, &export)
+	require.NoError(t, err)
+
+	assert.Equal(t, "8.1.0", export.StackVersion)
+	assert.Equal(t, []exportedService{
+		{
+			Name:        "elasticsearch",
+			Container:   "elastic-package-stack_elasticsearch_1",
+			Image:       "docker.elastic.co/elasticsearch/elasticsearch:8.1.0",
+			ImageDigest: "docker.elastic.co/elasticsearch/elasticsearch@sha256:es",
+			Status:      "running",
+			Networks:    map[string]string{"elastic-package-stack_default": "172.18.0.2"},
+		},
+		{
+			Name:        "kibana",
+			Container:   "elastic-package-stack_kibana_1",
+			Image:       "docker.elastic.co/kibana/kibana:8.1.0",
+			ImageDigest: "sha256:kibana",
+			Status:      "running",
+			Networks:    map[string]string{"elastic-package-stack_default": "172.18.0.3"},
+		},
+	}, export.Services)
+}
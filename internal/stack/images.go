@@ -0,0 +1,175 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stack
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/elastic-package/internal/docker"
+	"github.com/elastic/elastic-package/internal/install"
+	"github.com/elastic/elastic-package/internal/logger"
+	"github.com/elastic/elastic-package/internal/profile"
+)
+
+const (
+	pullMaxAttempts = 3
+	pullBackoff     = 5 * time.Second
+)
+
+// Images function returns references of Docker images used by the stack in the given version.
+func Images(stackVersion string) ([]string, error) {
+	appConfig, err := install.Configuration()
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read application configuration")
+	}
+
+	refs := appConfig.StackImageRefs(stackVersion)
+	return []string{
+		refs.Elasticsearch,
+		refs.Kibana,
+		refs.ElasticAgent,
+		profile.PackageRegistryBaseImage,
+	}, nil
+}
+
+// serviceImages function returns references of Docker images used by the selected services, or by all
+// services if none is selected.
+func serviceImages(stackVersion string, services []string) ([]string, error) {
+	services = withDependentServices(services)
+	if len(services) == 0 {
+		return Images(stackVersion)
+	}
+
+	appConfig, err := install.Configuration()
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read application configuration")
+	}
+	refs := appConfig.StackImageRefs(stackVersion)
+
+	var images []string
+	seen := make(map[string]bool)
+	for _, service := range services {
+		var image string
+		switch service {
+		case "elasticsearch":
+			image = refs.Elasticsearch
+		case "kibana":
+			image = refs.Kibana
+		case "fleet-server", "elastic-agent":
+			image = refs.ElasticAgent
+		case "package-registry":
+			image = profile.PackageRegistryBaseImage
+		default:
+			continue
+		}
+		if !seen[image] {
+			seen[image] = true
+			images = append(images, image)
+		}
+	}
+	return images, nil
+}
+
+// pullStackImages function pulls images of the selected services, retrying on transient errors.
+// If missingOnly is set, only images not present in the Docker daemon running the stack are pulled,
+// as Docker Compose does when booting up the stack.
+func pullStackImages(options Options, missingOnly bool) error {
+	images, err := serviceImages(options.StackVersion, options.Services)
+	if err != nil {
+		return err
+	}
+
+	for _, image := range images {
+		if missingOnly {
+			exists, err := docker.ImageExistsWithContext(options.dockerContext(), image)
+			if err != nil {
+				return err
+			}
+			if exists {
+				continue
+			}
+		}
+
+		err = docker.PullWithRetryWithContext(options.dockerContext(), image, pullMaxAttempts, pullBackoff)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCachedImages function verifies that images of the selected services are available locally,
+// so the stack can be booted up without pulling them.
+func checkCachedImages(options Options) error {
+	images, err := serviceImages(options.StackVersion, options.Services)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, image := range images {
+		exists, err := docker.ImageExistsWithContext(options.dockerContext(), image)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			missing = append(missing, image)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("image pulling is disabled, but some images are not available locally: %s", strings.Join(missing, ", "))
+	}
+
+	logger.Warnf("Image pulling is disabled, the following images are not refreshed: %s", strings.Join(images, ", "))
+	return nil
+}
+
+// SaveImages function writes every image of the stack in the selected version to a separate archive
+// in the destination directory, and returns paths of created archives. Images need to be present
+// in the Docker daemon running the stack.
+func SaveImages(options Options, destDir string) ([]string, error) {
+	images, err := Images(options.StackVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(destDir, 0755)
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating directory failed (path: %s)", destDir)
+	}
+
+	var paths []string
+	for _, image := range images {
+		path := filepath.Join(destDir, imageArchiveName(image))
+		err = docker.SaveImageWithContext(options.dockerContext(), image, path)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// LoadImages function loads images from archives created with SaveImages into the Docker daemon running the stack.
+func LoadImages(options Options, paths ...string) error {
+	for _, path := range paths {
+		err := docker.LoadImageWithContext(options.dockerContext(), path)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// imageArchiveName returns the file name of the image archive, e.g. "docker.elastic.co_kibana_kibana_8.1.0.tar".
+func imageArchiveName(image string) string {
+	return strings.NewReplacer("/", "_", ":", "_", "@", "_").Replace(image) + ".tar"
+}
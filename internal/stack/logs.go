@@ -5,7 +5,15 @@
 package stack
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -14,15 +22,89 @@ import (
 	"github.com/elastic/elastic-package/internal/install"
 )
 
-func dockerComposeLogs(serviceName string, snapshotFile string) ([]byte, error) {
-	p, err := compose.NewProject(DockerComposeProjectName, snapshotFile)
+const composeServiceLabel = "com.docker.compose.service"
+
+// Supported formats of log lines.
+const (
+	// LogsTextFormat prefixes every line with the service name.
+	LogsTextFormat = "text"
+
+	// LogsJSONFormat prints every line as a JSON document with the service, timestamp and message.
+	LogsJSONFormat = "json"
+
+	// LogsRawFormat prints lines as they are produced by containers.
+	LogsRawFormat = "raw"
+)
+
+// Logs function prints logs of the Elastic stack service containers to stdout in the given format.
+// All services are included if none is selected. If follow is set, the function keeps streaming until
+// containers stop. Only entries newer than since are included, unless since is zero.
+func Logs(options Options, follow bool, since time.Time, format string, services ...string) error {
+	switch format {
+	case LogsTextFormat, LogsJSONFormat, LogsRawFormat:
+	default:
+		return fmt.Errorf("unsupported logs format: %s (available: %s, %s, %s)", format, LogsTextFormat, LogsJSONFormat, LogsRawFormat)
+	}
+
+	containerIDs, err := dockerComposeContainerIDs(options, services...)
+	if err != nil {
+		return errors.Wrap(err, "can't read container IDs")
+	}
+	if len(containerIDs) == 0 {
+		return errors.New("there are no Elastic stack services running")
+	}
+
+	descriptions, err := docker.InspectContainersWithContext(options.dockerContext(), containerIDs...)
+	if err != nil {
+		return errors.Wrap(err, "can't inspect stack containers")
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make([]error, len(descriptions))
+	for i, description := range descriptions {
+		name, found := description.Config.Labels[composeServiceLabel]
+		if !found {
+			name = strings.TrimPrefix(description.Name, "/")
+		}
+
+		wg.Add(1)
+		go func(i int, containerID, name string) {
+			defer wg.Done()
+
+			w := &logWriter{service: name, format: format, out: os.Stdout, mu: &mu}
+			errs[i] = docker.LogsWithContext(options.dockerContext(), containerID, follow, since, w)
+			w.flush()
+		}(i, description.ID, name)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return errors.Wrap(err, "reading logs failed")
+		}
+	}
+	return nil
+}
+
+func dockerComposeLogs(serviceName string, options Options) ([]byte, error) {
+	p, err := compose.NewProject(DockerComposeProjectName, composeFiles(options)...)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create docker compose project")
 	}
 
+	appConfig, err := install.Configuration()
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read application configuration")
+	}
+
 	opts := compose.CommandOptions{
 		Env: newEnvBuilder().
-			withEnv(stackVariantAsEnv(install.DefaultStackVersion)).
+			withEnvs(appConfig.StackImageRefs(options.StackVersion).AsEnv()).
+			withEnv(stackVariantAsEnv(options.StackVersion)).
+			withEnvs(options.Profile.ComposeEnvVars()).
+			withEnvs(options.extraEnvs()).
+			withEnvs(options.dockerHostEnvs()).
 			build(),
 		Services: []string{serviceName},
 	}
@@ -34,7 +116,7 @@ func dockerComposeLogs(serviceName string, snapshotFile string) ([]byte, error)
 	return out, nil
 }
 
-func copyDockerInternalLogs(serviceName, outputPath string) error {
+func copyDockerInternalLogs(serviceName, outputPath string, options Options) error {
 	switch serviceName {
 	case elasticAgentService, fleetServerService:
 	default:
@@ -48,9 +130,87 @@ func copyDockerInternalLogs(serviceName, outputPath string) error {
 
 	outputPath = filepath.Join(outputPath, serviceName+"-internal")
 	serviceContainer := p.ContainerName(serviceName)
-	err = docker.Copy(serviceContainer, "/usr/share/elastic-agent/state/data/logs/default", outputPath)
+	err = docker.CopyWithContext(options.dockerContext(), serviceContainer, "/usr/share/elastic-agent/state/data/logs/default", outputPath)
 	if err != nil {
 		return errors.Wrap(err, "docker copy failed")
 	}
 	return nil
 }
+
+// logWriter writes complete lines of the service logs in the selected format to the output
+// shared with other writers.
+type logWriter struct {
+	service string
+	format  string
+	out     io.Writer
+	mu      *sync.Mutex
+
+	buf bytes.Buffer
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			break
+		}
+		line := w.buf.Next(i + 1)
+		if err := w.writeLine(line); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// flush writes the remaining incomplete line.
+func (w *logWriter) flush() {
+	if w.buf.Len() == 0 {
+		return
+	}
+	w.writeLine(append(w.buf.Bytes(), '\n'))
+	w.buf.Reset()
+}
+
+func (w *logWriter) writeLine(line []byte) error {
+	formatted, err := w.formatLine(line)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, err = w.out.Write(formatted)
+	return err
+}
+
+func (w *logWriter) formatLine(line []byte) ([]byte, error) {
+	switch w.format {
+	case LogsRawFormat:
+		return line, nil
+	case LogsJSONFormat:
+		// Lines start with the timestamp added by "docker logs --timestamps".
+		entry := struct {
+			Service   string `json:"service"`
+			Timestamp string `json:"timestamp,omitempty"`
+			Message   string `json:"message"`
+		}{
+			Service: w.service,
+			Message: strings.TrimSuffix(string(line), "\n"),
+		}
+		fields := strings.SplitN(entry.Message, " ", 2)
+		if _, err := time.Parse(time.RFC3339Nano, fields[0]); err == nil && len(fields) == 2 {
+			entry.Timestamp, entry.Message = fields[0], fields[1]
+		}
+
+		b, err := json.Marshal(entry)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't marshal log line")
+		}
+		return append(b, '\n'), nil
+	default:
+		return append([]byte(fmt.Sprintf("[%s] ", w.service)), line...), nil
+	}
+}
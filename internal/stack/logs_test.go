@@ -0,0 +1,39 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stack
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := &logWriter{service: "kibana", format: LogsTextFormat, out: &out, mu: &sync.Mutex{}}
+
+	w.Write([]byte("first line\nsecond "))
+	w.Write([]byte("line\nincomplete"))
+	assert.Equal(t, "[kibana] first line\n[kibana] second line\n", out.String())
+
+	w.flush()
+	assert.Equal(t, "[kibana] first line\n[kibana] second line\n[kibana] incomplete\n", out.String())
+}
+
+func TestLogWriterFormats(t *testing.T) {
+	line := "2022-03-01T10:00:00.123456789Z Kibana is now available\n"
+
+	var out bytes.Buffer
+	w := &logWriter{service: "kibana", format: LogsJSONFormat, out: &out, mu: &sync.Mutex{}}
+	w.Write([]byte(line))
+	assert.JSONEq(t, `{"service":"kibana","timestamp":"2022-03-01T10:00:00.123456789Z","message":"Kibana is now available"}`, out.String())
+
+	out.Reset()
+	w = &logWriter{service: "kibana", format: LogsRawFormat, out: &out, mu: &sync.Mutex{}}
+	w.Write([]byte(line))
+	assert.Equal(t, line, out.String())
+}
@@ -12,10 +12,22 @@ import (
 	"github.com/elastic/elastic-package/internal/docker"
 )
 
-// EnsureStackNetworkUp function verifies if stack network is up and running.
+// EnsureStackNetworkUp function verifies if stack network is up and running. The network is looked up
+// in the Docker daemon the stack has been booted up with.
 func EnsureStackNetworkUp() error {
-	_, err := docker.InspectNetwork(Network())
-	return errors.Wrap(err, "network not available")
+	options, err := RunningOptions(nil)
+	if err != nil {
+		return errors.Wrap(err, "can't read options of the running stack")
+	}
+
+	exists, err := docker.NetworkExistsWithContext(options.dockerContext(), Network())
+	if err != nil {
+		return errors.Wrap(err, "can't check network")
+	}
+	if !exists {
+		return errors.New("network not available")
+	}
+	return nil
 }
 
 // Network function returns the stack network name.
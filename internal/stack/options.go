@@ -4,14 +4,69 @@
 
 package stack
 
-import "github.com/elastic/elastic-package/internal/profile"
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/elastic/elastic-package/internal/profile"
+)
+
+// ProgressFunc receives progress of a long-running stack operation. Stage describes the completed step,
+// pct is the completed fraction of the operation, between 0 and 1.
+type ProgressFunc func(stage string, pct float64)
 
 // Options defines available image booting options.
 type Options struct {
 	DaemonMode   bool
 	StackVersion string
 
+	// PreserveVolumes keeps data volumes of the stack containers on tear down.
+	PreserveVolumes bool
+
 	Services []string
 
+	// Wait makes BootUp block until all services are healthy, up to WaitTimeout. It requires DaemonMode.
+	Wait        bool
+	WaitTimeout time.Duration
+
+	// OverrideFiles are Docker Compose files applied on top of the profile snapshot file, in order.
+	OverrideFiles []string
+
+	// NoPull disables pulling of images, locally cached images are used instead.
+	NoPull bool
+
+	// ExtraEnv are additional environment variables passed to Docker Compose, they take precedence
+	// over variables defined by the profile and the stack version.
+	ExtraEnv map[string]string
+
+	// RemoteDockerHost selects the Docker daemon used by the stack (e.g. tcp://10.0.0.5:2376),
+	// the local one is used if empty.
+	RemoteDockerHost string
+
 	Profile *profile.Profile
+
+	// ProgressFunc, if set, is notified about progress instead of printing it to stdout.
+	ProgressFunc ProgressFunc
+}
+
+func (o Options) reportProgress(stage string, pct float64) {
+	if o.ProgressFunc != nil {
+		o.ProgressFunc(stage, pct)
+	}
+}
+
+// extraEnvs returns extra environment variables in the KEY=VALUE form, sorted by key.
+func (o Options) extraEnvs() []string {
+	keys := make([]string, 0, len(o.ExtraEnv))
+	for key := range o.ExtraEnv {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	envs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		envs = append(envs, fmt.Sprintf("%s=%s", key, o.ExtraEnv[key]))
+	}
+	return envs
 }
@@ -0,0 +1,19 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stack
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Restart function restarts selected Elastic stack services without tearing down the stack.
+// All services are restarted if none is selected. Options need to match the running stack, see RunningOptions.
+func Restart(options Options, services ...string) error {
+	err := dockerComposeRestart(options, services)
+	if err != nil {
+		return errors.Wrap(err, "restarting docker containers failed")
+	}
+	return nil
+}
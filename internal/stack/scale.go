@@ -0,0 +1,33 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stack
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Scale function adjusts the number of containers of the running Elastic stack service.
+// Options need to match the running stack, see RunningOptions.
+func Scale(options Options, service string, replicas int) error {
+	if replicas < 0 {
+		return fmt.Errorf("number of replicas can't be negative (value: %d)", replicas)
+	}
+
+	containerIDs, err := dockerComposeContainerIDs(options, service)
+	if err != nil {
+		return errors.Wrapf(err, "can't read containers of service %s", service)
+	}
+	if len(containerIDs) == 0 {
+		return fmt.Errorf("service %s is not running in the stack", service)
+	}
+
+	err = dockerComposeScale(options, service, replicas)
+	if err != nil {
+		return errors.Wrapf(err, "scaling service %s failed", service)
+	}
+	return nil
+}
@@ -37,51 +37,69 @@ type kibanaConfiguration struct {
 }
 
 // ShellInit method exposes environment variables that can be used for testing purposes.
-func ShellInit(elasticStackProfile *profile.Profile) (string, error) {
+func ShellInit(options Options) (string, error) {
+	envVars, err := EnvVars(options)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(shellInitFormat,
+		envVars[ElasticsearchHostEnv],
+		envVars[ElasticsearchUsernameEnv],
+		envVars[ElasticsearchPasswordEnv],
+		envVars[KibanaHostEnv]), nil
+}
+
+// EnvVars function returns environment variables needed to connect to the Elastic stack, indexed by name.
+// Services of the stack running in a remote Docker daemon are exposed by the remote host.
+func EnvVars(options Options) (map[string]string, error) {
 	// Read Elasticsearch username and password from Kibana configuration file.
 	// FIXME read credentials from correct Kibana config file, not default
-	body, err := os.ReadFile(elasticStackProfile.FetchPath(profile.KibanaConfigDefaultFile))
+	body, err := os.ReadFile(options.Profile.FetchPath(profile.KibanaConfigDefaultFile))
 	if err != nil {
-		return "", errors.Wrap(err, "error reading Kibana config file")
+		return nil, errors.Wrap(err, "error reading Kibana config file")
 	}
 
 	var kibanaCfg kibanaConfiguration
 	err = yaml.Unmarshal(body, &kibanaCfg)
 	if err != nil {
-		return "", errors.Wrap(err, "unmarshalling Kibana configuration failed")
+		return nil, errors.Wrap(err, "unmarshalling Kibana configuration failed")
 	}
 
 	// Read Elasticsearch and Kibana hostnames from Elastic Stack Docker Compose configuration file.
-	p, err := compose.NewProject(DockerComposeProjectName, elasticStackProfile.FetchPath(profile.SnapshotFile))
+	p, err := compose.NewProject(DockerComposeProjectName, composeFiles(options)...)
 	if err != nil {
-		return "", errors.Wrap(err, "could not create docker compose project")
+		return nil, errors.Wrap(err, "could not create docker compose project")
 	}
 
 	appConfig, err := install.Configuration()
 	if err != nil {
-		return "", errors.Wrap(err, "can't read application configuration")
+		return nil, errors.Wrap(err, "can't read application configuration")
 	}
 
 	serviceComposeConfig, err := p.Config(compose.CommandOptions{
 		Env: newEnvBuilder().
-			withEnvs(appConfig.StackImageRefs(install.DefaultStackVersion).AsEnv()).
-			withEnvs(elasticStackProfile.ComposeEnvVars()).
-			withEnv(stackVariantAsEnv(install.DefaultStackVersion)).
+			withEnvs(appConfig.StackImageRefs(options.StackVersion).AsEnv()).
+			withEnv(stackVariantAsEnv(options.StackVersion)).
+			withEnvs(options.Profile.ComposeEnvVars()).
+			withEnvs(options.extraEnvs()).
+			withEnvs(options.dockerHostEnvs()).
 			build(),
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "could not get Docker Compose configuration for service")
+		return nil, errors.Wrap(err, "could not get Docker Compose configuration for service")
 	}
 
 	kib := serviceComposeConfig.Services["kibana"]
-	kibHostPort := fmt.Sprintf("http://%s:%d", kib.Ports[0].ExternalIP, kib.Ports[0].ExternalPort)
+	kibHostPort := fmt.Sprintf("http://%s:%d", options.serviceHost(kib.Ports[0].ExternalIP), kib.Ports[0].ExternalPort)
 
 	es := serviceComposeConfig.Services["elasticsearch"]
-	esHostPort := fmt.Sprintf("http://%s:%d", es.Ports[0].ExternalIP, es.Ports[0].ExternalPort)
-
-	return fmt.Sprintf(shellInitFormat,
-		esHostPort,
-		kibanaCfg.ElasticsearchUsername,
-		kibanaCfg.ElasticsearchPassword,
-		kibHostPort), nil
+	esHostPort := fmt.Sprintf("http://%s:%d", options.serviceHost(es.Ports[0].ExternalIP), es.Ports[0].ExternalPort)
+
+	return map[string]string{
+		ElasticsearchHostEnv:     esHostPort,
+		ElasticsearchUsernameEnv: kibanaCfg.ElasticsearchUsername,
+		ElasticsearchPasswordEnv: kibanaCfg.ElasticsearchPassword,
+		KibanaHostEnv:            kibHostPort,
+	}, nil
 }
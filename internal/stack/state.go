@@ -0,0 +1,125 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stack
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/elastic-package/internal/configuration/locations"
+	"github.com/elastic/elastic-package/internal/install"
+	"github.com/elastic/elastic-package/internal/profile"
+)
+
+// runningOptionsFile is the file in the stack directory storing options of the running stack.
+// All profiles share the same Docker Compose project, so there is a single file for all of them.
+const runningOptionsFile = DockerComposeProjectName + ".json"
+
+// runningOptions are the options of BootUp needed to recompute the Docker Compose project of the running stack.
+type runningOptions struct {
+	StackVersion  string            `json:"stack_version"`
+	OverrideFiles []string          `json:"override_files,omitempty"`
+	ExtraEnv      map[string]string `json:"extra_env,omitempty"`
+	DockerHost    string            `json:"docker_host,omitempty"`
+}
+
+// RunningOptions function returns options the running stack has been booted up with, using the given profile.
+// Commands operating on the running stack need them, so Docker Compose computes the same project
+// configuration. The default stack version is used if the stack hasn't been booted up.
+func RunningOptions(elasticStackProfile *profile.Profile) (Options, error) {
+	options := Options{
+		StackVersion: install.DefaultStackVersion,
+		Profile:      elasticStackProfile,
+	}
+
+	path, err := runningOptionsPath()
+	if err != nil {
+		return Options{}, err
+	}
+
+	body, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return options, nil
+	}
+	if err != nil {
+		return Options{}, errors.Wrap(err, "can't read options of the running stack")
+	}
+
+	var running runningOptions
+	err = json.Unmarshal(body, &running)
+	if err != nil {
+		return Options{}, errors.Wrapf(err, "can't unmarshal options of the running stack (path: %s)", path)
+	}
+
+	options.StackVersion = running.StackVersion
+	options.OverrideFiles = running.OverrideFiles
+	options.ExtraEnv = running.ExtraEnv
+	options.RemoteDockerHost = running.DockerHost
+	return options, nil
+}
+
+// saveRunningOptions function stores options of the booted up stack, so they can be read with RunningOptions.
+func saveRunningOptions(options Options) error {
+	running := runningOptions{
+		StackVersion: options.StackVersion,
+		ExtraEnv:     options.ExtraEnv,
+		DockerHost:   options.RemoteDockerHost,
+	}
+
+	// Override files are stored with absolute paths, as following commands can be run from other directories.
+	for _, overrideFile := range options.OverrideFiles {
+		path, err := filepath.Abs(overrideFile)
+		if err != nil {
+			return errors.Wrapf(err, "can't find absolute path of override file %s", overrideFile)
+		}
+		running.OverrideFiles = append(running.OverrideFiles, path)
+	}
+
+	body, err := json.MarshalIndent(running, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "can't marshal options of the running stack")
+	}
+
+	path, err := runningOptionsPath()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return errors.Wrapf(err, "can't create stack directory %s", filepath.Dir(path))
+	}
+
+	err = os.WriteFile(path, body, 0644)
+	if err != nil {
+		return errors.Wrap(err, "can't write options of the running stack")
+	}
+	return nil
+}
+
+// removeRunningOptions function removes options stored by saveRunningOptions, once the stack is taken down.
+func removeRunningOptions() error {
+	path, err := runningOptionsPath()
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, "can't remove options of the running stack")
+	}
+	return nil
+}
+
+func runningOptionsPath() (string, error) {
+	loc, err := locations.NewLocationManager()
+	if err != nil {
+		return "", errors.Wrap(err, "locating stack directory failed")
+	}
+	return filepath.Join(loc.StackDir(), runningOptionsFile), nil
+}
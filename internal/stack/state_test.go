@@ -0,0 +1,52 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stack
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-package/internal/install"
+)
+
+func TestRunningOptions(t *testing.T) {
+	t.Setenv("ELASTIC_PACKAGE_DATA_HOME", t.TempDir())
+
+	options, err := RunningOptions(nil)
+	require.NoError(t, err)
+	assert.Equal(t, install.DefaultStackVersion, options.StackVersion)
+
+	err = saveRunningOptions(Options{
+		StackVersion:     "8.1.0",
+		OverrideFiles:    []string{"override.yml"},
+		ExtraEnv:         map[string]string{"ES_JAVA_OPTS": "-Xmx1g"},
+		RemoteDockerHost: "tcp://10.0.0.5:2376",
+		Services:         []string{"elasticsearch"},
+	})
+	require.NoError(t, err)
+
+	overrideFile, err := filepath.Abs("override.yml")
+	require.NoError(t, err)
+
+	options, err = RunningOptions(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "8.1.0", options.StackVersion)
+	assert.Equal(t, []string{overrideFile}, options.OverrideFiles)
+	assert.Equal(t, map[string]string{"ES_JAVA_OPTS": "-Xmx1g"}, options.ExtraEnv)
+	assert.Equal(t, "tcp://10.0.0.5:2376", options.RemoteDockerHost)
+	assert.Empty(t, options.Services)
+
+	err = removeRunningOptions()
+	require.NoError(t, err)
+	err = removeRunningOptions()
+	require.NoError(t, err)
+
+	options, err = RunningOptions(nil)
+	require.NoError(t, err)
+	assert.Equal(t, install.DefaultStackVersion, options.StackVersion)
+}
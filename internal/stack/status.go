@@ -0,0 +1,63 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stack
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/elastic-package/internal/docker"
+)
+
+// ServiceStatus describes the state of a single Elastic stack service container.
+type ServiceStatus struct {
+	Name     string
+	Image    string
+	Status   string
+	Health   string
+	ExitCode int
+}
+
+// IsReady method checks if the service is running and healthy, or if it doesn't define a healthcheck.
+func (s ServiceStatus) IsReady() bool {
+	return s.Status == "running" && (s.Health == "" || s.Health == "healthy")
+}
+
+// Status function returns the status of all Elastic stack service containers.
+func Status(options Options) ([]ServiceStatus, error) {
+	containerIDs, err := dockerComposeContainerIDs(options)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read container IDs")
+	}
+	if len(containerIDs) == 0 {
+		return nil, nil
+	}
+
+	descriptions, err := docker.InspectContainersWithContext(options.dockerContext(), containerIDs...)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't inspect stack containers")
+	}
+
+	var statuses []ServiceStatus
+	for _, description := range descriptions {
+		status := ServiceStatus{
+			Name:     strings.TrimPrefix(description.Name, "/"),
+			Image:    description.Config.Image,
+			Status:   description.State.Status,
+			ExitCode: description.State.ExitCode,
+		}
+		if description.State.Health != nil {
+			status.Health = description.State.Health.Status
+		}
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses, nil
+}
@@ -6,18 +6,16 @@ package stack
 
 import (
 	"github.com/pkg/errors"
-
-	"github.com/elastic/elastic-package/internal/docker"
-	"github.com/elastic/elastic-package/internal/profile"
 )
 
 // Update pulls down the most recent versions of the Docker images.
 func Update(options Options) error {
-	err := docker.Pull(profile.PackageRegistryBaseImage)
+	err := pullStackImages(options, false)
 	if err != nil {
-		return errors.Wrap(err, "pulling package-registry docker image failed")
+		return errors.Wrap(err, "pulling stack images failed")
 	}
 
+	// Images of services defined in override files are pulled by Docker Compose.
 	err = dockerComposePull(options)
 	if err != nil {
 		return errors.Wrap(err, "updating docker images failed")
@@ -0,0 +1,71 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package stack
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/elastic-package/internal/signal"
+)
+
+// waitForStackInterval is the check interval for WaitForStack().
+const waitForStackInterval = 1 * time.Second
+
+// WaitForStack function blocks until all Elastic stack services are healthy or the timeout expires.
+// Progress is printed to stdout, unless the ProgressFunc is set.
+func WaitForStack(options Options, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	if options.ProgressFunc == nil {
+		fmt.Print("Waiting for the Elastic stack services to be healthy")
+		defer fmt.Println()
+	}
+
+	for {
+		if signal.SIGINT() {
+			return errors.New("SIGINT: cancel waiting for the Elastic stack")
+		}
+
+		servicesStatus, err := Status(options)
+		if err != nil {
+			return errors.Wrap(err, "can't read stack status")
+		}
+		if len(servicesStatus) == 0 {
+			return errors.New("there are no Elastic stack services running")
+		}
+
+		var notReady []string
+		for _, service := range servicesStatus {
+			if service.Status == "exited" {
+				if service.ExitCode == 0 {
+					continue // one-off container finished successfully
+				}
+				return fmt.Errorf("service container %s exited with code %d", service.Name, service.ExitCode)
+			}
+
+			if !service.IsReady() {
+				notReady = append(notReady, service.Name)
+			}
+		}
+
+		if len(notReady) == 0 {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout waiting for the Elastic stack, services still unhealthy: %s", strings.Join(notReady, ", "))
+		}
+
+		if options.ProgressFunc == nil {
+			fmt.Print(".")
+		}
+		// NOTE: using sleep does not guarantee interval but it's ok for this use case
+		time.Sleep(waitForStackInterval)
+	}
+}